@@ -0,0 +1,53 @@
+// Package buildinfo 保存构建时注入的版本信息，构建时通过ldflags设置：
+//
+//	go build -ldflags "-X seaguard-admin-backend/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X seaguard-admin-backend/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// 未注入时回退到Go工具链记录的VCS信息
+package buildinfo
+
+import "runtime/debug"
+
+var (
+	// Commit 构建所用的git提交
+	Commit = ""
+	// BuildTime 构建时间（UTC，RFC 3339）
+	BuildTime = ""
+)
+
+const unknown = "unknown"
+
+func init() {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if Commit == "" {
+				Commit = setting.Value
+			}
+		case "vcs.time":
+			if BuildTime == "" {
+				BuildTime = setting.Value
+			}
+		}
+	}
+}
+
+// CommitOrUnknown 返回构建提交，未知时返回"unknown"
+func CommitOrUnknown() string {
+	if Commit == "" {
+		return unknown
+	}
+	return Commit
+}
+
+// BuildTimeOrUnknown 返回构建时间，未知时返回"unknown"
+func BuildTimeOrUnknown() string {
+	if BuildTime == "" {
+		return unknown
+	}
+	return BuildTime
+}
@@ -0,0 +1,327 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/ratelimit"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config 应用配置，从环境变量中读取
+type Config struct {
+	// EncryptionKeys 字段加密密钥列表，格式为"keyID:base64密钥,keyID:base64密钥"，密钥长度32字节
+	EncryptionKeys string
+	// EncryptionActiveKey 用于加密新数据的密钥ID，为空时使用列表中的第一个
+	EncryptionActiveKey string
+	// BlindIndexKey 盲索引HMAC密钥（base64）
+	BlindIndexKey string
+	// DevMode 开发模式，未配置加密密钥时使用内置的开发密钥，生产环境不得开启
+	DevMode bool
+
+	// RetentionEnabled 是否启用数据保留后台任务
+	RetentionEnabled bool
+	// RetentionDryRun 后台任务只生成报告而不实际清除数据
+	RetentionDryRun bool
+	// RetentionInterval 数据保留任务的执行间隔
+	RetentionInterval time.Duration
+	// RetentionRules 数据保留规则，可通过SEAGUARD_RETENTION_RULES以JSON数组覆盖
+	RetentionRules []models.RetentionRule
+
+	// RequestTimeout 单个请求的默认处理超时
+	RequestTimeout time.Duration
+	// RouteTimeouts 按路由覆盖的超时，键为"方法 路由模板"，如"POST /api/admin/retention/run"
+	RouteTimeouts map[string]time.Duration
+
+	// HTTPAddr HTTP服务监听地址
+	HTTPAddr string
+	// ReadHeaderTimeout 读取请求头的超时
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout 读取完整请求（含请求体）的超时
+	ReadTimeout time.Duration
+	// WriteTimeout 写出响应的超时，应大于最长的路由处理超时
+	WriteTimeout time.Duration
+	// IdleTimeout keep-alive连接的空闲超时
+	IdleTimeout time.Duration
+	// MaxHeaderBytes 请求头的最大字节数
+	MaxHeaderBytes int
+	// MaxBodyBytes 请求体的最大字节数
+	MaxBodyBytes int64
+	// ShutdownTimeout 收到退出信号后等待进行中请求完成的最长时间
+	ShutdownTimeout time.Duration
+	// TLSCertFile、TLSKeyFile 均配置时启用HTTPS，收到SIGHUP信号时重新加载证书
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// MetricsEnabled 是否开放/metrics指标接口
+	MetricsEnabled bool
+
+	// LogLevel 日志级别：debug、info、warn、error
+	LogLevel string
+	// LogFormat 日志格式：json或text
+	LogFormat string
+	// SlowQueryThreshold 超过该耗时的数据库语句以warn级别记录
+	SlowQueryThreshold time.Duration
+
+	// TraceExporter 链路追踪导出方式：none、otlp、stdout、file；otlp的端点通过OTEL_EXPORTER_OTLP_*配置
+	TraceExporter string
+	// TraceFile 导出方式为file时写入的文件
+	TraceFile string
+	// TraceSampleRatio 链路采样比例，0到1之间
+	TraceSampleRatio float64
+
+	// RateLimitEnabled 是否对/api下的请求限流
+	RateLimitEnabled bool
+	// RateLimitStore 令牌桶存储：memory（单实例）或db（多实例共享）
+	RateLimitStore string
+	// RateLimitGCInterval 存储为db时清理已补满令牌桶的间隔
+	RateLimitGCInterval time.Duration
+	// RateLimitPolicies 限流策略，可通过SEAGUARD_RATE_LIMIT_POLICIES以JSON数组覆盖
+	RateLimitPolicies []ratelimit.Policy
+	// RateLimitAPIKeys 已登记的API Key，按API Key限流时只有这些Key拥有独立的令牌桶
+	RateLimitAPIKeys []string
+	// TrustedProxies 可信反向代理的IP或CIDR，只有来自这些地址的X-Forwarded-For才用于确定客户端IP
+	TrustedProxies []string
+
+	// ActivityCacheTTL 活动列表进程内缓存的有效期，本实例的写入会立即使缓存失效
+	ActivityCacheTTL time.Duration
+
+	// IdempotencyTTL 幂等请求的响应保存时长，期间使用相同Idempotency-Key的重试会重放该响应
+	IdempotencyTTL time.Duration
+
+	// SeriesHorizon 活动系列提前生成场次的时间范围
+	SeriesHorizon time.Duration
+	// SeriesInterval 后台任务补齐活动系列场次的间隔
+	SeriesInterval time.Duration
+
+	// CORSOrigins 允许跨域访问的来源，如"https://admin.example.com"，为空时只允许同源访问
+	CORSOrigins []string
+	// CORSCredentials 是否允许跨域请求携带Cookie等凭据，使用Bearer Token时无需开启
+	CORSCredentials bool
+	// CORSMaxAge 浏览器缓存预检请求结果的时长
+	CORSMaxAge time.Duration
+
+	// SecurityHeaders 是否设置CSP、X-Frame-Options等安全响应头
+	SecurityHeaders bool
+	// HSTSMaxAge Strict-Transport-Security的有效期，为0时不发送；通过HTTPS提供服务时再开启
+	HSTSMaxAge time.Duration
+	// HSTSSubdomains HSTS是否作用于所有子域名
+	HSTSSubdomains bool
+}
+
+// defaultRetentionRules 默认数据保留规则
+var defaultRetentionRules = []models.RetentionRule{
+	{
+		Name:      "clear-id-card-after-activity",
+		Entity:    models.RetentionEntityRegistration,
+		Field:     "id_card",
+		Anchor:    models.RetentionAnchorActivityEnd,
+		AfterDays: 90,
+	},
+	{
+		Name:      "delete-rejected-registrations",
+		Entity:    models.RetentionEntityRegistration,
+		Status:    models.RegistrationRejected,
+		Anchor:    models.RetentionAnchorUpdatedAt,
+		AfterDays: 365,
+	},
+}
+
+// defaultRateLimitPolicies 默认限流策略：登录和注册按IP严格限制，报名按用户限制，其余请求按IP和用户宽松限制
+var defaultRateLimitPolicies = []ratelimit.Policy{
+	{Name: "login", Routes: []string{"POST /api/auth/login"}, Key: ratelimit.KeyIP, Requests: 5, PeriodSeconds: 60, Burst: 5},
+	{Name: "register", Routes: []string{"POST /api/auth/register"}, Key: ratelimit.KeyIP, Requests: 10, PeriodSeconds: 3600, Burst: 5},
+	{Name: "activity-signup", Routes: []string{"POST /api/activities/:id/register"}, Key: ratelimit.KeyUser, Requests: 10, PeriodSeconds: 60, Burst: 5},
+	{Name: "ip-default", Key: ratelimit.KeyIP, Requests: 20, PeriodSeconds: 1, Burst: 40},
+	{Name: "user-default", Key: ratelimit.KeyUser, Requests: 10, PeriodSeconds: 1, Burst: 20},
+	{Name: "api-key-default", Key: ratelimit.KeyAPIKey, Requests: 10, PeriodSeconds: 1, Burst: 20},
+}
+
+// defaultRouteTimeouts 耗时较长的路由默认使用的超时，可通过SEAGUARD_ROUTE_TIMEOUTS覆盖
+var defaultRouteTimeouts = map[string]time.Duration{
+	"GET /api/admin/retention/report": 2 * time.Minute,
+	"POST /api/admin/retention/run":   2 * time.Minute,
+	"GET /api/volunteer/my-data":      30 * time.Second,
+	"POST /api/users/:id/anonymize":   30 * time.Second,
+}
+
+var App *Config
+
+// LoadConfig 加载应用配置
+func LoadConfig() {
+	App = &Config{
+		EncryptionKeys:      getEnv("SEAGUARD_ENCRYPTION_KEYS", ""),
+		EncryptionActiveKey: getEnv("SEAGUARD_ENCRYPTION_ACTIVE_KEY", ""),
+		BlindIndexKey:       getEnv("SEAGUARD_BLIND_INDEX_KEY", ""),
+		DevMode:             getEnvBool("SEAGUARD_DEV_MODE", false),
+		RetentionEnabled:    getEnvBool("SEAGUARD_RETENTION_ENABLED", true),
+		RetentionDryRun:     getEnvBool("SEAGUARD_RETENTION_DRY_RUN", false),
+		RetentionInterval:   getEnvDuration("SEAGUARD_RETENTION_INTERVAL", 24*time.Hour),
+		RetentionRules:      defaultRetentionRules,
+		RequestTimeout:      getEnvDuration("SEAGUARD_REQUEST_TIMEOUT", 10*time.Second),
+		RouteTimeouts:       defaultRouteTimeouts,
+		HTTPAddr:            getEnv("SEAGUARD_HTTP_ADDR", ":8080"),
+		ReadHeaderTimeout:   getEnvDuration("SEAGUARD_READ_HEADER_TIMEOUT", 5*time.Second),
+		ReadTimeout:         getEnvDuration("SEAGUARD_READ_TIMEOUT", 30*time.Second),
+		WriteTimeout:        getEnvDuration("SEAGUARD_WRITE_TIMEOUT", 3*time.Minute),
+		IdleTimeout:         getEnvDuration("SEAGUARD_IDLE_TIMEOUT", 2*time.Minute),
+		MaxHeaderBytes:      int(getEnvInt("SEAGUARD_MAX_HEADER_BYTES", 1<<20)),
+		MaxBodyBytes:        getEnvInt("SEAGUARD_MAX_BODY_BYTES", 1<<20),
+		ShutdownTimeout:     getEnvDuration("SEAGUARD_SHUTDOWN_TIMEOUT", 30*time.Second),
+		TLSCertFile:         getEnv("SEAGUARD_TLS_CERT_FILE", ""),
+		TLSKeyFile:          getEnv("SEAGUARD_TLS_KEY_FILE", ""),
+		MetricsEnabled:      getEnvBool("SEAGUARD_METRICS_ENABLED", true),
+		LogLevel:            getEnv("SEAGUARD_LOG_LEVEL", "info"),
+		LogFormat:           getEnv("SEAGUARD_LOG_FORMAT", "json"),
+		SlowQueryThreshold:  getEnvDuration("SEAGUARD_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+		TraceExporter:       getEnv("SEAGUARD_TRACE_EXPORTER", "none"),
+		TraceFile:           getEnv("SEAGUARD_TRACE_FILE", "traces.jsonl"),
+		TraceSampleRatio:    getEnvFloat("SEAGUARD_TRACE_SAMPLE_RATIO", 1),
+		RateLimitEnabled:    getEnvBool("SEAGUARD_RATE_LIMIT_ENABLED", true),
+		RateLimitStore:      getEnv("SEAGUARD_RATE_LIMIT_STORE", "memory"),
+		RateLimitGCInterval: getEnvDuration("SEAGUARD_RATE_LIMIT_GC_INTERVAL", 10*time.Minute),
+		RateLimitPolicies:   defaultRateLimitPolicies,
+		RateLimitAPIKeys:    splitList(getEnv("SEAGUARD_RATE_LIMIT_API_KEYS", "")),
+		TrustedProxies:      splitList(getEnv("SEAGUARD_TRUSTED_PROXIES", "")),
+		ActivityCacheTTL:    getEnvDuration("SEAGUARD_ACTIVITY_CACHE_TTL", 30*time.Second),
+		IdempotencyTTL:      getEnvDuration("SEAGUARD_IDEMPOTENCY_TTL", 24*time.Hour),
+		SeriesHorizon:       getEnvDuration("SEAGUARD_SERIES_HORIZON", 8*7*24*time.Hour),
+		SeriesInterval:      getEnvDuration("SEAGUARD_SERIES_GENERATE_INTERVAL", time.Hour),
+		CORSOrigins:         splitList(getEnv("SEAGUARD_CORS_ALLOWED_ORIGINS", "")),
+		CORSCredentials:     getEnvBool("SEAGUARD_CORS_ALLOW_CREDENTIALS", false),
+		CORSMaxAge:          getEnvDuration("SEAGUARD_CORS_MAX_AGE", 12*time.Hour),
+		SecurityHeaders:     getEnvBool("SEAGUARD_SECURITY_HEADERS_ENABLED", true),
+		HSTSMaxAge:          getEnvDuration("SEAGUARD_HSTS_MAX_AGE", 0),
+		HSTSSubdomains:      getEnvBool("SEAGUARD_HSTS_INCLUDE_SUBDOMAINS", false),
+	}
+
+	if raw := getEnv("SEAGUARD_ROUTE_TIMEOUTS", ""); raw != "" {
+		timeouts, err := parseRouteTimeouts(raw)
+		if err != nil {
+			log.Fatal("Invalid SEAGUARD_ROUTE_TIMEOUTS:", err)
+		}
+		for route, timeout := range timeouts {
+			App.RouteTimeouts[route] = timeout
+		}
+	}
+
+	if raw := getEnv("SEAGUARD_RETENTION_RULES", ""); raw != "" {
+		var rules []models.RetentionRule
+		if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+			log.Fatal("Invalid SEAGUARD_RETENTION_RULES:", err)
+		}
+		App.RetentionRules = rules
+	}
+	for _, rule := range App.RetentionRules {
+		if err := rule.Validate(); err != nil {
+			log.Fatal("Invalid retention rule:", err)
+		}
+	}
+
+	if raw := getEnv("SEAGUARD_RATE_LIMIT_POLICIES", ""); raw != "" {
+		var policies []ratelimit.Policy
+		if err := json.Unmarshal([]byte(raw), &policies); err != nil {
+			log.Fatal("Invalid SEAGUARD_RATE_LIMIT_POLICIES:", err)
+		}
+		App.RateLimitPolicies = policies
+	}
+	for _, policy := range App.RateLimitPolicies {
+		if err := policy.Validate(); err != nil {
+			log.Fatal("Invalid rate limit policy:", err)
+		}
+	}
+	if App.RateLimitStore != "memory" && App.RateLimitStore != "db" {
+		log.Fatal("SEAGUARD_RATE_LIMIT_STORE must be memory or db")
+	}
+
+	if (App.TLSCertFile == "") != (App.TLSKeyFile == "") {
+		log.Fatal("SEAGUARD_TLS_CERT_FILE and SEAGUARD_TLS_KEY_FILE must be set together")
+	}
+
+	if App.EncryptionKeys == "" || App.BlindIndexKey == "" {
+		if !App.DevMode {
+			log.Fatal("SEAGUARD_ENCRYPTION_KEYS and SEAGUARD_BLIND_INDEX_KEY must be set unless SEAGUARD_DEV_MODE is enabled")
+		}
+		log.Println("警告: 未配置字段加密密钥，正在使用仅供开发环境的默认密钥")
+	}
+}
+
+// parseRouteTimeouts 解析按路由配置的超时，格式为"GET /api/volunteers=5s,POST /api/admin/retention/run=5m"
+func parseRouteTimeouts(raw string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		route, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("missing timeout in %q", item)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout in %q", item)
+		}
+		timeouts[strings.Join(strings.Fields(route), " ")] = timeout
+	}
+	return timeouts, nil
+}
+
+// splitList 解析逗号分隔的列表，忽略空项
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnv 读取环境变量，未设置时返回默认值
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+// getEnvBool 读取布尔类型的环境变量
+func getEnvBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvInt 读取整数类型的环境变量，非正数视为未设置
+func getEnvInt(key string, fallback int64) int64 {
+	value, err := strconv.ParseInt(getEnv(key, ""), 10, 64)
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// getEnvFloat 读取浮点类型的环境变量
+func getEnvFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, ""), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvDuration 读取时间间隔类型的环境变量，如"30s"、"24h"
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, ""))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
@@ -2,25 +2,67 @@ package config
 
 import (
 	"log"
+	"log/slog"
+	"seaguard-admin-backend/logging"
+	"seaguard-admin-backend/metrics"
 	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/tracing"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var DB *gorm.DB
 
-// InitDatabase 初始化数据库连接
-func InitDatabase() {
+// InitDatabase 初始化数据库连接，数据库日志写入logger
+func InitDatabase(logger *slog.Logger) {
 	var err error
-	DB, err = gorm.Open(sqlite.Open("seaguard.db"), &gorm.Config{})
+	// 事务以IMMEDIATE模式开启并设置忙等待，避免并发写入时出现database is locked错误
+	DB, err = gorm.Open(sqlite.Open("seaguard.db?_busy_timeout=5000&_txlock=immediate"), &gorm.Config{
+		Logger: logging.NewGormLogger(logger, App.SlowQueryThreshold),
+	})
 	if err != nil {
 		log.Fatal("Failed to connect database:", err)
 	}
 
+	// 记录每条语句的耗时、错误和链路span
+	if err := DB.Use(metrics.GormPlugin{}); err != nil {
+		log.Fatal("Failed to register database metrics:", err)
+	}
+	if err := DB.Use(tracing.GormPlugin{}); err != nil {
+		log.Fatal("Failed to register database tracing:", err)
+	}
+
 // 自动迁移表结构
-err = DB.AutoMigrate(&models.User{}, &models.Activity{}, &models.Volunteer{}, &models.Registration{})
+err = DB.AutoMigrate(&models.User{}, &models.Activity{}, &models.Category{}, &models.Tag{}, &models.ActivitySeries{}, &models.Volunteer{}, &models.Registration{}, &models.AuditLog{}, &models.RateLimitBucket{}, &models.IdempotencyRecord{}, &models.SchemaMigration{})
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
+
+	if err := encryptLegacyData(); err != nil {
+		log.Fatal("Failed to encrypt legacy data:", err)
+	}
+
+	if err := recordSchemaVersion(); err != nil {
+		log.Fatal("Failed to record schema version:", err)
+	}
+}
+
+// recordSchemaVersion 迁移完成后记录当前表结构版本，供就绪检查比对
+func recordSchemaVersion() error {
+	return DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.SchemaMigration{
+		Version:   models.SchemaVersion,
+		AppliedAt: time.Now(),
+	}).Error
+}
+
+// CloseDatabase 关闭数据库连接，在服务退出前调用
+func CloseDatabase() error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/utils/fieldcrypt"
+
+	"gorm.io/gorm/clause"
+)
+
+// reencryptBatchSize 每批重新加密的记录数，避免IN查询的参数超出数据库限制
+const reencryptBatchSize = 500
+
+// encryptLegacyData 加密启用字段加密前写入的明文数据，将使用旧密钥加密的数据改用当前密钥重新加密，并补全盲索引
+func encryptLegacyData() error {
+	var missingIndex []uint
+	err := DB.Model(&models.Registration{}).
+		Where("id_card <> '' AND (id_card_index IS NULL OR id_card_index = '')").
+		Pluck("id", &missingIndex).Error
+	if err != nil {
+		return err
+	}
+	registrationIDs, err := staleEncryptedIDs("registrations", "id_card", "phone", "emergency_contact", "emergency_phone")
+	if err != nil {
+		return err
+	}
+	// 读取时已用原密钥解密，保存时序列化器以当前密钥重新加密
+	err = forEachBatch(append(registrationIDs, missingIndex...), func(ids []uint) error {
+		var registrations []models.Registration
+		if err := DB.Where("id IN ?", ids).Find(&registrations).Error; err != nil {
+			return err
+		}
+		for i := range registrations {
+			if err := DB.Omit(clause.Associations).Save(&registrations[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	volunteerIDs, err := staleEncryptedIDs("volunteers", "phone", "address")
+	if err != nil {
+		return err
+	}
+	// 回收站中的志愿者也需要重新加密，否则轮换密钥后恢复的记录无法解密
+	return forEachBatch(volunteerIDs, func(ids []uint) error {
+		var volunteers []models.Volunteer
+		if err := DB.Unscoped().Where("id IN ?", ids).Find(&volunteers).Error; err != nil {
+			return err
+		}
+		for i := range volunteers {
+			if err := DB.Unscoped().Omit(clause.Associations).Save(&volunteers[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// staleEncryptedIDs 返回表中任一加密列仍为明文或不是以当前密钥加密的记录ID，按列的原始值判断而不经过序列化器解密
+func staleEncryptedIDs(table string, columns ...string) ([]uint, error) {
+	rows, err := DB.Table(table).Select(append([]string{"id"}, columns...)).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activeID := fieldcrypt.ActiveKeyID()
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		values := make([]*string, len(columns))
+		dest := []interface{}{&id}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			if value != nil && *value != "" && fieldcrypt.KeyID(*value) != activeID {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	return ids, rows.Err()
+}
+
+// forEachBatch 按reencryptBatchSize分批处理ids
+func forEachBatch(ids []uint, fn func(ids []uint) error) error {
+	for start := 0; start < len(ids); start += reencryptBatchSize {
+		if err := fn(ids[start:min(start+reencryptBatchSize, len(ids))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -34,11 +34,61 @@ const docTemplate = `{
                     "活动管理"
                 ],
                 "summary": "获取可报名活动列表（志愿者）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "只返回属于该分类ID的活动",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只返回带有该标签的活动",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的ETag，列表未变化时返回304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的Last-Modified，列表此后未变化时返回304",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "活动列表",
+                        "description": "活动列表，按开始时间排序，附带ETag和Last-Modified响应头",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Activity"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "列表未变化"
+                    },
+                    "400": {
+                        "description": "无效的分类ID",
                         "schema": {
-                            "$ref": "#/definitions/models.ActivitiesResponse"
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "403": {
@@ -79,19 +129,37 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Activity"
+                            "$ref": "#/definitions/models.ActivityRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等Key，网络重试时使用相同的值，避免重复创建",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "创建成功的活动信息",
                         "schema": {
-                            "$ref": "#/definitions/models.Activity"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Activity"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "请求参数无效",
+                        "description": "请求参数无效或分类不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -102,6 +170,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "使用相同Idempotency-Key的请求正在处理中",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key已用于内容不同的请求",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -112,13 +192,93 @@ const docTemplate = `{
             }
         },
         "/activities/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取活动信息及剩余名额、待审核报名数（pending_count）、当前用户的报名状态和签到时间窗口；草稿活动仅管理员可见",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动管理"
+                ],
+                "summary": "获取活动详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "活动ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的ETag，详情未变化时返回304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "活动详情，ETag响应头为当前用户所见内容的摘要，修改活动时的If-Match请使用version字段",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ActivityDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "详情未变化"
+                    },
+                    "400": {
+                        "description": "无效的ID参数",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "活动不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "更新指定ID的活动信息（需要管理员权限）",
+                "description": "更新指定ID的活动信息；活动系列中的场次单独修改后，修改系列时不再随之调整（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -137,25 +297,43 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "活动当前版本号（即version字段或上次响应的ETag），不一致时返回412",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
                     {
                         "description": "活动信息",
                         "name": "activity",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Activity"
+                            "$ref": "#/definitions/models.ActivityRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新后的活动信息",
+                        "description": "更新后的活动信息，ETag响应头为新版本号",
                         "schema": {
-                            "$ref": "#/definitions/models.Activity"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Activity"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "无效的ID参数或请求数据",
+                        "description": "无效的ID参数或请求数据，或分类不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -166,6 +344,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "活动不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "活动已有报名，不能改回草稿",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "活动已被其他人修改",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -180,7 +376,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "删除指定ID的活动（需要管理员权限）",
+                "description": "将指定ID的活动移入回收站，可通过回收站恢复或彻底删除；删除系列中的场次时该日期加入系列的跳过列表（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -219,6 +415,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "活动不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -262,6 +464,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.RegistrationRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等Key，网络重试时使用相同的值，避免重复创建",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -272,7 +480,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "无效的活动ID或报名信息",
+                        "description": "无效的活动ID或报名信息（手机号、身份证号格式错误等，详见errors字段）",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -284,13 +492,31 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "无权限访问",
+                        "description": "无权限访问或不满足活动年龄要求",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "活动不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "409": {
-                        "description": "已经报名过该活动",
+                        "description": "已经报名过该活动、活动不在报名阶段、名额已满，或使用相同Idempotency-Key的请求正在处理中",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key已用于内容不同的请求",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "报名请求过于频繁，Retry-After响应头给出可重试的秒数",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -329,15 +555,36 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的ETag，报名信息未变化时返回304",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "报名信息",
+                        "description": "报名信息，ETag响应头为当前版本号",
                         "schema": {
-                            "$ref": "#/definitions/models.Registration"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Registration"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
+                    "304": {
+                        "description": "报名信息未变化"
+                    },
                     "400": {
                         "description": "无效的活动ID",
                         "schema": {
@@ -378,7 +625,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取指定活动的所有报名记录（需要志愿者权限）",
+                "description": "获取指定活动的所有报名记录（需要志愿者权限）。证件号码、手机号等个人信息默认脱敏，拥有pii:view权限时返回完整信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -402,7 +649,22 @@ const docTemplate = `{
                     "200": {
                         "description": "报名记录列表",
                         "schema": {
-                            "$ref": "#/definitions/models.RegistrationsResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Registration"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -444,11 +706,61 @@ const docTemplate = `{
                     "活动管理"
                 ],
                 "summary": "获取活动列表（管理员）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "只返回属于该分类ID的活动",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只返回带有该标签的活动",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的ETag，列表未变化时返回304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的Last-Modified，列表此后未变化时返回304",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "活动列表",
+                        "description": "活动列表，附带ETag和Last-Modified响应头",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Activity"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "列表未变化"
+                    },
+                    "400": {
+                        "description": "无效的分类ID",
                         "schema": {
-                            "$ref": "#/definitions/models.ActivitiesResponse"
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "403": {
@@ -466,9 +778,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "用户登录并获取认证token",
+        "/admin/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按时间倒序获取最近的审计日志（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -476,35 +793,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "认证管理"
+                    "审计日志"
                 ],
-                "summary": "用户登录",
+                "summary": "获取审计日志",
                 "parameters": [
                     {
-                        "description": "登录信息",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "返回条数，默认100，最大500",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "登录成功",
+                        "description": "审计日志列表",
                         "schema": {
-                            "$ref": "#/definitions/models.LoginResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AuditLog"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数无效",
+                    "403": {
+                        "description": "无权限访问",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "用户名或密码错误",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -512,14 +841,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/password": {
-            "put": {
+        "/admin/categories/stats": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "修改用户密码",
+                "description": "按分类统计已发布活动的数量、名额、已占用名额和已通过的报名数，不含草稿和已删除的活动；属于多个分类的活动在每个分类中都计入（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -527,29 +856,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
-                ],
-                "summary": "修改密码",
-                "parameters": [
-                    {
-                        "description": "密码修改信息",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ChangePasswordRequest"
-                        }
-                    }
+                    "活动分类"
                 ],
+                "summary": "获取分类统计",
                 "responses": {
                     "200": {
-                        "description": "密码修改成功",
+                        "description": "分类统计，按名称排序",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CategoryStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "400": {
-                        "description": "请求参数无效或密码错误",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -557,9 +896,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "创建新用户账号。如果role为volunteer，则同时创建志愿者信息",
+        "/admin/retention/report": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "统计各保留规则下已过期的记录数，不修改任何数据（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -567,29 +911,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "认证管理"
-                ],
-                "summary": "用户注册",
-                "parameters": [
-                    {
-                        "description": "注册信息。当role为volunteer时，需要提供name、phone、email、address等志愿者信息",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RegisterRequest"
-                        }
-                    }
+                    "数据保留"
                 ],
+                "summary": "数据保留试运行",
                 "responses": {
                     "200": {
-                        "description": "注册成功",
+                        "description": "试运行报告",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RetentionReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "400": {
-                        "description": "请求参数无效: 1. 用户名已存在 2. 必填字段缺失 3. role为volunteer时未提供志愿者信息",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -597,14 +948,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/registrations/{id}/status": {
-            "put": {
+        "/admin/retention/rules": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "更新指定报名记录的状态（需要志愿者权限）",
+                "description": "获取当前生效的数据保留规则（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -612,39 +963,76 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "报名管理"
-                ],
-                "summary": "更新报名状态",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "报名ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "状态信息（可选值：pending待审核、approved已通过、rejected已拒绝）",
-                        "name": "status",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.StatusUpdateRequest"
-                        }
-                    }
+                    "数据保留"
                 ],
+                "summary": "获取数据保留规则",
                 "responses": {
                     "200": {
-                        "description": "状态更新成功",
+                        "description": "保留规则列表",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.RetentionRule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "无效的报名ID或状态值",
+                    "403": {
+                        "description": "无权限访问",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
+                    }
+                }
+            }
+        },
+        "/admin/retention/run": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "立即清除已过保留期的数据，每条实际生效的规则都会写入审计日志（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "数据保留"
+                ],
+                "summary": "立即执行数据保留策略",
+                "responses": {
+                    "200": {
+                        "description": "执行报告",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RetentionReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "403": {
                         "description": "无权限访问",
@@ -661,14 +1049,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/admin/series": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取所有用户信息（仅管理员可用）",
+                "description": "获取所有重复举行的活动系列（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -676,14 +1064,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "活动系列"
                 ],
-                "summary": "获取用户列表",
+                "summary": "获取活动系列",
                 "responses": {
                     "200": {
-                        "description": "用户列表",
+                        "description": "活动系列列表，最近创建的在前",
                         "schema": {
-                            "$ref": "#/definitions/models.UsersResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ActivitySeries"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "403": {
@@ -701,14 +1104,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
-            "delete": {
+        "/admin/trash": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "删除指定用户（仅管理员可用）",
+                "description": "获取已删除但尚未彻底删除的用户、志愿者和活动（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -716,29 +1119,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
-                ],
-                "summary": "删除用户",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "回收站"
                 ],
+                "summary": "获取回收站",
                 "responses": {
                     "200": {
-                        "description": "用户删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "无效的用户ID",
+                        "description": "回收站内容",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Trash"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "403": {
@@ -756,14 +1156,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/status": {
-            "put": {
+        "/admin/trash/{type}/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "更新指定用户的状态（仅管理员可用）",
+                "description": "彻底删除回收站中的记录，不可恢复。用户：清除其报名记录中的个人信息并删除志愿者信息；活动：删除全部报名记录（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -771,48 +1171,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "回收站"
                 ],
-                "summary": "更新用户状态",
+                "summary": "彻底删除",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "id",
+                        "enum": [
+                            "users",
+                            "volunteers",
+                            "activities"
+                        ],
+                        "type": "string",
+                        "description": "记录类型",
+                        "name": "type",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "状态信息",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.StatusUpdateRequest"
-                        }
+                        "type": "integer",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "状态更新成功",
+                        "description": "彻底删除成功",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "无效的用户ID或状态",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "无权限访问",
+                        "description": "无效的类型或ID",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "404": {
+                        "description": "回收站中不存在该记录",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -820,14 +1217,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/volunteer/my-info": {
-            "get": {
+        "/admin/trash/{type}/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "已登录的志愿者用户获取自己的个人信息",
+                "description": "恢复已删除的记录，恢复用户时一并恢复其志愿者信息（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -835,49 +1232,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "志愿者"
+                    "回收站"
+                ],
+                "summary": "从回收站恢复",
+                "parameters": [
+                    {
+                        "enum": [
+                            "users",
+                            "volunteers",
+                            "activities"
+                        ],
+                        "type": "string",
+                        "description": "记录类型",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "获取个人志愿者信息",
                 "responses": {
                     "200": {
-                        "description": "志愿者个人信息",
-                        "schema": {
-                            "$ref": "#/definitions/models.Volunteer"
-                        }
-                    },
-                    "401": {
-                        "description": "未登录",
+                        "description": "恢复成功",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "403": {
-                        "description": "无权限访问：非志愿者用户",
+                    "400": {
+                        "description": "无效的类型或ID",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
-                        "description": "未找到志愿者信息",
+                        "description": "回收站中不存在该记录",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "409": {
+                        "description": "所属用户仍在回收站中",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/language": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "已登录的志愿者用户更新自己的个人信息",
+                "description": "设置当前用户的界面语言（zh或en），优先于Accept-Language请求头",
                 "consumes": [
                     "application/json"
                 ],
@@ -885,53 +1299,99 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "志愿者"
+                    "用户管理"
                 ],
-                "summary": "更新个人志愿者信息",
+                "summary": "更新语言偏好",
                 "parameters": [
                     {
-                        "description": "志愿者个人信息 (姓名、电话、邮箱、地址)",
-                        "name": "info",
+                        "description": "语言偏好",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateVolunteerInfoRequest"
+                            "$ref": "#/definitions/models.UpdateLanguageRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "语言偏好更新成功",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数无效：1. 必填字段缺失 2. 邮箱格式错误",
+                        "description": "请求参数无效或不支持的语言",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "用户登录并获取认证token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "认证管理"
+                ],
+                "summary": "用户登录",
+                "parameters": [
+                    {
+                        "description": "登录信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登录成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    "401": {
-                        "description": "未登录",
+                    "400": {
+                        "description": "请求参数无效",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "403": {
-                        "description": "无权限访问：非志愿者用户",
+                    "401": {
+                        "description": "用户名或密码错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "未找到志愿者信息",
+                    "403": {
+                        "description": "用户账号已被禁用",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "429": {
+                        "description": "登录尝试过于频繁，Retry-After响应头给出可重试的秒数",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -939,14 +1399,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/volunteers": {
-            "get": {
+        "/auth/password": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取所有志愿者的列表（需要管理员权限）",
+                "description": "修改用户密码",
                 "consumes": [
                     "application/json"
                 ],
@@ -954,37 +1414,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "志愿者管理"
+                    "用户管理"
                 ],
-                "summary": "获取志愿者列表",
-                "responses": {
-                    "200": {
-                        "description": "志愿者列表",
+                "summary": "修改密码",
+                "parameters": [
+                    {
+                        "description": "密码修改信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.VolunteersResponse"
+                            "$ref": "#/definitions/models.ChangePasswordRequest"
                         }
-                    },
-                    "403": {
-                        "description": "无权限访问",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "密码修改成功",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "400": {
+                        "description": "请求参数无效或密码错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "创建一个新的志愿者信息（需要管理员权限）",
+                "description": "创建新用户账号。如果role为volunteer，则同时创建志愿者信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -992,41 +1454,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "志愿者管理"
+                    "认证管理"
                 ],
-                "summary": "创建新志愿者",
+                "summary": "用户注册",
                 "parameters": [
                     {
-                        "description": "志愿者信息",
-                        "name": "volunteer",
+                        "description": "注册信息。当role为volunteer时，需要提供name、phone、email、address等志愿者信息",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Volunteer"
+                            "$ref": "#/definitions/models.RegisterRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等Key，网络重试时使用相同的值，避免重复注册；未登录时按客户端IP区分",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "创建成功的志愿者信息",
+                    "200": {
+                        "description": "注册成功",
                         "schema": {
-                            "$ref": "#/definitions/models.Volunteer"
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数无效",
+                        "description": "请求参数无效: 1. 必填字段缺失 2. role为volunteer时未提供志愿者信息 3. 无效的用户角色",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "403": {
-                        "description": "无权限访问",
+                    "409": {
+                        "description": "用户名已存在，或使用相同Idempotency-Key的请求正在处理中",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "422": {
+                        "description": "Idempotency-Key已用于内容不同的请求",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "注册请求过于频繁，Retry-After响应头给出可重试的秒数",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1034,14 +1508,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/volunteers/{id}": {
-            "put": {
+        "/categories": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "更新指定ID的志愿者信息（需要管理员权限）",
+                "description": "获取所有活动分类，可用于筛选活动列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -1049,32 +1523,184 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "志愿者管理"
+                    "活动分类"
                 ],
-                "summary": "更新志愿者信息",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "志愿者ID",
-                        "name": "id",
+                "summary": "获取活动分类",
+                "responses": {
+                    "200": {
+                        "description": "分类列表，按名称排序",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Category"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建一个新的活动分类（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动分类"
+                ],
+                "summary": "创建活动分类",
+                "parameters": [
+                    {
+                        "description": "分类信息",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CategoryRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等Key，网络重试时使用相同的值，避免重复创建",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "创建成功的分类",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数无效",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "分类名称已存在，或使用相同Idempotency-Key的请求正在处理中",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key已用于内容不同的请求",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新指定ID的分类名称和说明（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动分类"
+                ],
+                "summary": "更新活动分类",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "分类ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "志愿者信息",
-                        "name": "volunteer",
+                        "description": "分类信息",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Volunteer"
+                            "$ref": "#/definitions/models.CategoryRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新后的志愿者信息",
+                        "description": "更新后的分类",
                         "schema": {
-                            "$ref": "#/definitions/models.Volunteer"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Category"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1089,6 +1715,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "分类不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "分类名称已存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -1103,7 +1741,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "删除指定ID的志愿者（需要管理员权限）",
+                "description": "删除指定ID的分类，已关联的活动保留但不再属于该分类（需要管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -1111,13 +1749,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "志愿者管理"
+                    "活动分类"
                 ],
-                "summary": "删除志愿者",
+                "summary": "删除活动分类",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "志愿者ID",
+                        "description": "分类ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1125,7 +1763,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "志愿者删除成功",
+                        "description": "分类删除成功",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1142,6 +1780,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "分类不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -1150,262 +1794,2840 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.ActivitiesResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Activity"
-                    }
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
         },
-        "models.Activity": {
-            "type": "object",
-            "properties": {
-                "capacity": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "date": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "location": {
-                    "type": "string"
-                },
-                "registered": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+        "/registrations/{id}/reveal": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回单条报名记录未脱敏的个人信息，需要pii:reveal权限并填写查看原因，每次查看都会记录审计日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "报名管理"
+                ],
+                "summary": "查看报名记录完整个人信息",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "报名ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "查看原因",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RevealRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "完整报名信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Registration"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "无效的报名ID或未填写原因",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "缺少pii:reveal权限",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "未找到报名记录",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
                 }
             }
         },
-        "models.ChangePasswordRequest": {
-            "type": "object",
-            "required": [
-                "new_password",
-                "old_password"
-            ],
-            "properties": {
-                "new_password": {
-                    "type": "string",
-                    "example": "new_password"
-                },
-                "old_password": {
+        "/registrations/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新指定报名记录的状态（需要志愿者权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "报名管理"
+                ],
+                "summary": "更新报名状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "报名ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "报名记录当前版本号（即version字段或上次响应的ETag），不一致时返回412",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "状态信息（可选值：pending待审核、approved已通过、rejected已拒绝）",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegistrationStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "状态更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的报名ID或状态值",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "未找到报名记录",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "报名记录已被其他人修改",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/series": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按重复规则创建活动系列，并立即生成滚动窗口内的场次，之后由后台任务定期补齐（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动系列"
+                ],
+                "summary": "创建活动系列",
+                "parameters": [
+                    {
+                        "description": "系列模板和重复规则",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SeriesRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等Key，网络重试时使用相同的值，避免重复创建",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "创建成功的系列及已生成的场次",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SeriesDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数无效、重复规则无法产生场次或分类不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "使用相同Idempotency-Key的请求正在处理中",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key已用于内容不同的请求",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取活动系列的模板、重复规则及已生成的全部场次（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动系列"
+                ],
+                "summary": "获取活动系列详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "系列ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "系列详情，ETag响应头为当前版本号",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SeriesDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "无效的ID参数",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "活动系列不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "替换系列模板和重复规则。effective_from之后开始、未单独修改过的场次按新模板调整，不再符合新规则的场次从未有人报名时删除、有报名时标记为已取消；\n只修改单个场次请使用PUT /activities/{id}，修改后该场次不再随系列调整（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动系列"
+                ],
+                "summary": "修改活动系列（此后所有场次）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "系列ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "系列当前版本号（即version字段或上次响应的ETag），不一致时返回412",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "系列模板和重复规则",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SeriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新后的系列及场次，ETag响应头为新版本号",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SeriesDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "无效的ID参数或请求数据、重复规则无法产生场次或分类不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "活动系列不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "活动系列已取消",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "系列或场次已被其他人修改",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "停止生成新场次，尚未开始的场次从未有人报名时删除、有报名时标记为已取消；已结束的场次不受影响（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动系列"
+                ],
+                "summary": "取消活动系列",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "系列ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取消后的系列及场次",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SeriesDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "无效的ID参数",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "活动系列不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "场次已被其他人修改",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取已发布活动使用的标签及使用次数，按使用次数降序排列",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动分类"
+                ],
+                "summary": "获取活动标签",
+                "responses": {
+                    "200": {
+                        "description": "标签列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.TagUsage"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取所有用户信息（仅管理员可用）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户列表",
+                "responses": {
+                    "200": {
+                        "description": "用户列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.User"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将指定用户及其志愿者信息移入回收站，可通过回收站恢复或彻底删除（仅管理员可用）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "删除用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "用户删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的用户ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/anonymize": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员代为执行用户的被遗忘权请求，清除个人信息并停用账号，保留统计数据",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "匿名化用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "个人数据已匿名化",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的用户ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/permissions": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "设置指定用户的附加权限（仅管理员可用），可选值：pii:view查看未脱敏个人信息、pii:reveal通过审计接口查看单条完整信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新用户附加权限",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户当前版本号（即version字段或上次响应的ETag），不一致时返回412",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "权限列表",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "权限更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的用户ID或权限",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "用户已被其他人修改",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新指定用户的状态（仅管理员可用）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新用户状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户当前版本号（即version字段或上次响应的ETag），不一致时返回412",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "状态信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StatusUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "状态更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的用户ID或状态",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "用户已被其他人修改",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteer/forget": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "验证密码后清除当前用户的个人信息并停用账号，报名状态与服务时长等统计数据会以匿名形式保留",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "志愿者"
+                ],
+                "summary": "注销并匿名化个人数据",
+                "parameters": [
+                    {
+                        "description": "当前密码",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ForgetMeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "个人数据已匿名化",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数无效或密码错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteer/my-data": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "导出当前用户的账号信息、志愿者档案、报名记录和服务时长。format=zip时以ZIP附件形式下载",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "tags": [
+                    "志愿者"
+                ],
+                "summary": "导出个人数据",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "zip"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "导出格式",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "个人数据",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PersonalDataExport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "不支持的导出格式",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteer/my-info": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "已登录的志愿者用户获取自己的个人信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "志愿者"
+                ],
+                "summary": "获取个人志愿者信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "上次响应的ETag，信息未变化时返回304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "志愿者个人信息，ETag响应头为当前版本号",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Volunteer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "信息未变化"
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问：非志愿者用户",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "未找到志愿者信息",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "已登录的志愿者用户更新自己的个人信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "志愿者"
+                ],
+                "summary": "更新个人志愿者信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "志愿者信息当前版本号（即version字段或上次响应的ETag），不一致时返回412",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "志愿者个人信息 (姓名、电话、邮箱、地址)",
+                        "name": "info",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateVolunteerInfoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数无效：1. 必填字段缺失 2. 邮箱格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问：非志愿者用户",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "未找到志愿者信息",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "志愿者信息已被其他人修改",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取所有志愿者的列表（需要管理员权限）。手机号、邮箱、地址默认脱敏，拥有pii:view权限时返回完整信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "志愿者管理"
+                ],
+                "summary": "获取志愿者列表",
+                "responses": {
+                    "200": {
+                        "description": "志愿者列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Volunteer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建一个新的志愿者信息（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "志愿者管理"
+                ],
+                "summary": "创建新志愿者",
+                "parameters": [
+                    {
+                        "description": "志愿者信息",
+                        "name": "volunteer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Volunteer"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等Key，网络重试时使用相同的值，避免重复创建",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "创建成功的志愿者信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Volunteer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数无效",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "使用相同Idempotency-Key的请求正在处理中",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key已用于内容不同的请求",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新指定ID的志愿者信息（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "志愿者管理"
+                ],
+                "summary": "更新志愿者信息",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "志愿者ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "志愿者信息当前版本号（即version字段或上次响应的ETag），不一致时返回412",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "志愿者信息",
+                        "name": "volunteer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Volunteer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新后的志愿者信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Volunteer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "无效的ID参数或请求数据",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "未找到志愿者信息",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "志愿者信息已被其他人修改",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将指定ID的志愿者移入回收站，可通过回收站恢复或彻底删除（需要管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "志愿者管理"
+                ],
+                "summary": "删除志愿者",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "志愿者ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "志愿者删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的ID参数",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限访问",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/reveal": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回单个志愿者未脱敏的个人信息，需要pii:reveal权限并填写查看原因，每次查看都会记录审计日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "志愿者管理"
+                ],
+                "summary": "查看志愿者完整个人信息",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "志愿者ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "查看原因",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RevealRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "完整志愿者信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Volunteer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "无效的ID参数或未填写原因",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "缺少pii:reveal权限",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "未找到志愿者信息",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Activity": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "max_age": {
+                    "description": "最高年龄要求，0表示不限",
+                    "type": "integer"
+                },
+                "min_age": {
+                    "description": "最低年龄要求，0表示不限",
+                    "type": "integer"
+                },
+                "occurrence": {
+                    "description": "系列场次的原定日期，用于避免重复生成",
+                    "type": "string"
+                },
+                "organizer_email": {
+                    "type": "string"
+                },
+                "organizer_name": {
+                    "description": "活动负责人，供报名者咨询",
+                    "type": "string"
+                },
+                "organizer_phone": {
+                    "type": "string"
+                },
+                "registered": {
+                    "description": "已占用名额：待审核和已通过的报名",
+                    "type": "integer"
+                },
+                "series_detached": {
+                    "description": "场次已单独修改，修改系列时不再随之调整",
+                    "type": "boolean"
+                },
+                "series_id": {
+                    "description": "所属活动系列，单独创建的活动为空",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "乐观锁版本号，每次更新加一",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ActivityDetail": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "check_in": {
+                    "$ref": "#/definitions/models.CheckInWindow"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "max_age": {
+                    "description": "最高年龄要求，0表示不限",
+                    "type": "integer"
+                },
+                "min_age": {
+                    "description": "最低年龄要求，0表示不限",
+                    "type": "integer"
+                },
+                "my_registration_status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ]
+                },
+                "occurrence": {
+                    "description": "系列场次的原定日期，用于避免重复生成",
+                    "type": "string"
+                },
+                "organizer_email": {
+                    "type": "string"
+                },
+                "organizer_name": {
+                    "description": "活动负责人，供报名者咨询",
+                    "type": "string"
+                },
+                "organizer_phone": {
+                    "type": "string"
+                },
+                "pending_count": {
+                    "description": "已占用名额、等待审核的报名数",
+                    "type": "integer"
+                },
+                "registered": {
+                    "description": "已占用名额：待审核和已通过的报名",
+                    "type": "integer"
+                },
+                "seats_left": {
+                    "type": "integer"
+                },
+                "series_detached": {
+                    "description": "场次已单独修改，修改系列时不再随之调整",
+                    "type": "boolean"
+                },
+                "series_id": {
+                    "description": "所属活动系列，单独创建的活动为空",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "乐观锁版本号，每次更新加一",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ActivityRequest": {
+            "type": "object",
+            "required": [
+                "capacity",
+                "date",
+                "location",
+                "tags",
+                "title"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 30
+                },
+                "category_ids": {
+                    "description": "CategoryIDs 活动所属分类，更新时整体替换",
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-06-01T09:00:00+08:00"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "清理海滩垃圾，保护海洋环境"
+                },
+                "draft": {
+                    "description": "Draft 为true时活动保存为草稿，只有管理员可见；更新时设为false即发布",
+                    "type": "boolean",
+                    "example": false
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-06-01T12:00:00+08:00"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "青岛市第一海水浴场"
+                },
+                "max_age": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "min_age": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 18
+                },
+                "organizer_email": {
+                    "type": "string",
+                    "example": "organizer@example.com"
+                },
+                "organizer_name": {
+                    "type": "string",
+                    "example": "王老师"
+                },
+                "organizer_phone": {
+                    "type": "string",
+                    "example": "13900139000"
+                },
+                "tags": {
+                    "description": "Tags 自由填写的标签，不存在的标签自动创建，更新时整体替换",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "潜水",
+                        "亲子"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "海滩清洁日"
+                }
+            }
+        },
+        "models.ActivitySeries": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "description": "每场时长，0表示不设结束时间",
+                    "type": "integer"
+                },
+                "generated_until": {
+                    "description": "已生成场次的截止时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "max_age": {
+                    "type": "integer"
+                },
+                "min_age": {
+                    "type": "integer"
+                },
+                "organizer_email": {
+                    "type": "string"
+                },
+                "organizer_name": {
+                    "type": "string"
+                },
+                "organizer_phone": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "$ref": "#/definitions/models.Recurrence"
+                },
+                "start_at": {
+                    "description": "首场开始时间，同时决定各场次的开始时刻和时区",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "cancelled"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "乐观锁版本号，每次更新加一",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "description": "操作人用户ID，系统任务为0",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "integer"
+                },
+                "target_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "清理海滩及近岸垃圾"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "海滩清洁"
+                }
+            }
+        },
+        "models.CategoryStats": {
+            "type": "object",
+            "properties": {
+                "activities": {
+                    "description": "活动总数",
+                    "type": "integer",
+                    "example": 12
+                },
+                "approved_registrations": {
+                    "type": "integer",
+                    "example": 280
+                },
+                "capacity": {
+                    "description": "名额合计",
+                    "type": "integer",
+                    "example": 360
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "fill_rate": {
+                    "description": "已占用名额占名额合计的比例",
+                    "type": "number",
+                    "example": 0.83
+                },
+                "name": {
+                    "type": "string",
+                    "example": "海滩清洁"
+                },
+                "registered": {
+                    "description": "已占用名额合计",
+                    "type": "integer",
+                    "example": 298
+                },
+                "upcoming_activities": {
+                    "description": "报名中且尚未开始的活动数",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "example": "new_password"
+                },
+                "old_password": {
+                    "type": "string",
+                    "example": "old_password"
+                }
+            }
+        },
+        "models.CheckInWindow": {
+            "type": "object",
+            "properties": {
+                "closes_at": {
+                    "type": "string"
+                },
+                "open": {
+                    "description": "当前是否处于签到时间内",
+                    "type": "boolean"
+                },
+                "opens_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "phone"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "phone必须是有效的手机号码"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "cn_mobile"
+                }
+            }
+        },
+        "models.ForgetMeRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "your_password"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "your_password"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "john_doe"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.LoginUser"
+                }
+            }
+        },
+        "models.LoginUser": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PersonalDataExport": {
+            "type": "object",
+            "properties": {
+                "exported_at": {
+                    "type": "string"
+                },
+                "registrations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RegistrationExport"
+                    }
+                },
+                "total_hours": {
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "volunteer": {
+                    "$ref": "#/definitions/models.Volunteer"
+                }
+            }
+        },
+        "models.Recurrence": {
+            "type": "object",
+            "required": [
+                "frequency"
+            ],
+            "properties": {
+                "dates": {
+                    "type": "array",
+                    "maxItems": 366,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-06-01"
+                    ]
+                },
+                "exceptions": {
+                    "type": "array",
+                    "maxItems": 366,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-10-04"
+                    ]
+                },
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "weekly",
+                        "monthly",
+                        "dates"
+                    ],
+                    "example": "weekly"
+                },
+                "interval": {
+                    "description": "每几周或每几个月重复一次，0视为1",
+                    "type": "integer",
+                    "maximum": 52,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "month_days": {
+                    "description": "为空时取首场的日期，当月没有该日时跳过",
+                    "type": "array",
+                    "maxItems": 31,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        15
+                    ]
+                },
+                "until": {
+                    "description": "最后可能举行的日期（含），为空表示不结束",
+                    "type": "string",
+                    "example": "2025-12-31"
+                },
+                "weekdays": {
+                    "description": "0为周日；为空时取首场的星期",
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        6
+                    ]
+                }
+            }
+        },
+        "models.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "北京市海淀区"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "zhangsan@example.com"
+                },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "zh",
+                        "en"
+                    ],
+                    "example": "zh"
+                },
+                "name": {
+                    "description": "Volunteer个人信息（当role为volunteer时必填）",
+                    "type": "string",
+                    "example": "张三"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "your_password"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "13800138000"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "volunteer"
+                    ],
+                    "example": "volunteer"
+                },
+                "username": {
+                    "description": "User账号信息",
+                    "type": "string",
+                    "example": "john_doe"
+                }
+            }
+        },
+        "models.Registration": {
+            "type": "object",
+            "properties": {
+                "activity_id": {
+                    "type": "integer"
+                },
+                "birth_date": {
+                    "type": "string"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "document_type": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "emergency_contact": {
+                    "type": "string"
+                },
+                "emergency_phone": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "id_card": {
+                    "description": "证件号码",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "添加UserID字段",
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "乐观锁版本号，每次更新加一",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RegistrationExport": {
+            "type": "object",
+            "properties": {
+                "activity_date": {
+                    "type": "string"
+                },
+                "activity_id": {
+                    "type": "integer"
+                },
+                "activity_title": {
+                    "type": "string"
+                },
+                "birth_date": {
+                    "type": "string"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "document_type": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "emergency_contact": {
+                    "type": "string"
+                },
+                "emergency_phone": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "id_card": {
+                    "description": "证件号码",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "添加UserID字段",
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "乐观锁版本号，每次更新加一",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RegistrationRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "emergency_contact",
+                "emergency_phone",
+                "id_card",
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "birth_date": {
+                    "type": "string",
+                    "example": "1990-01-01"
+                },
+                "document_type": {
+                    "description": "证件类型，默认为居民身份证；非身份证时需提供出生日期和性别",
+                    "type": "string",
+                    "enum": [
+                        "id_card",
+                        "passport",
+                        "hk_macau_permit",
+                        "taiwan_permit"
+                    ],
+                    "example": "id_card"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "zhangsan@example.com"
+                },
+                "emergency_contact": {
+                    "type": "string",
+                    "example": "李四"
+                },
+                "emergency_phone": {
+                    "type": "string",
+                    "example": "13900139000"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female"
+                    ],
+                    "example": "male"
+                },
+                "id_card": {
+                    "type": "string",
+                    "example": "110101199001011237"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "张三"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "13800138000"
+                }
+            }
+        },
+        "models.RegistrationStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
                     "type": "string",
-                    "example": "old_password"
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "approved"
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.Response": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
+                "code": {
                     "type": "string",
-                    "example": "your_password"
+                    "example": "OK"
                 },
-                "username": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                },
+                "message": {
                     "type": "string",
-                    "example": "john_doe"
+                    "example": "操作成功"
                 }
             }
         },
-        "models.LoginResponse": {
+        "models.RetentionReport": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "token": {
+                "finished_at": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RetentionRuleResult"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
                 }
             }
         },
-        "models.RegisterRequest": {
+        "models.RetentionRule": {
             "type": "object",
-            "required": [
-                "password",
-                "role",
-                "username"
-            ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "example": "北京市海淀区"
+                "after_days": {
+                    "type": "integer",
+                    "example": 90
                 },
-                "email": {
+                "anchor": {
                     "type": "string",
-                    "example": "zhangsan@example.com"
+                    "example": "activity_end"
                 },
-                "name": {
-                    "description": "Volunteer个人信息（当role为volunteer时必填）",
+                "entity": {
                     "type": "string",
-                    "example": "张三"
+                    "example": "registration"
                 },
-                "password": {
+                "field": {
                     "type": "string",
-                    "example": "your_password"
+                    "example": "id_card"
                 },
-                "phone": {
+                "name": {
                     "type": "string",
-                    "example": "13800138000"
+                    "example": "clear-id-card"
                 },
-                "role": {
+                "status": {
+                    "description": "仅作用于该状态的记录，为空表示不限",
                     "type": "string",
-                    "enum": [
-                        "admin",
-                        "volunteer"
-                    ],
-                    "example": "volunteer"
+                    "example": "rejected"
+                }
+            }
+        },
+        "models.RetentionRuleResult": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "description": "实际清除或删除的记录数，试运行时为0",
+                    "type": "integer"
                 },
-                "username": {
-                    "description": "User账号信息",
+                "cutoff": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "matched": {
+                    "description": "已过保留期的记录数",
+                    "type": "integer"
+                },
+                "rule": {
+                    "$ref": "#/definitions/models.RetentionRule"
+                }
+            }
+        },
+        "models.RevealRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
                     "type": "string",
-                    "example": "john_doe"
+                    "maxLength": 200,
+                    "example": "核对保险投保信息"
                 }
             }
         },
-        "models.Registration": {
+        "models.SeriesDetail": {
             "type": "object",
             "properties": {
-                "activity_id": {
+                "capacity": {
                     "type": "integer"
                 },
-                "create_time": {
-                    "type": "string"
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "emergency_contact": {
+                "description": {
                     "type": "string"
                 },
-                "emergency_phone": {
+                "duration_minutes": {
+                    "description": "每场时长，0表示不设结束时间",
+                    "type": "integer"
+                },
+                "generated_until": {
+                    "description": "已生成场次的截止时间",
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "id_card": {
+                "location": {
                     "type": "string"
                 },
-                "name": {
+                "max_age": {
+                    "type": "integer"
+                },
+                "min_age": {
+                    "type": "integer"
+                },
+                "occurrences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Activity"
+                    }
+                },
+                "organizer_email": {
                     "type": "string"
                 },
-                "phone": {
+                "organizer_name": {
+                    "type": "string"
+                },
+                "organizer_phone": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "$ref": "#/definitions/models.Recurrence"
+                },
+                "start_at": {
+                    "description": "首场开始时间，同时决定各场次的开始时刻和时区",
                     "type": "string"
                 },
                 "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "cancelled"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "添加UserID字段",
+                "version": {
+                    "description": "乐观锁版本号，每次更新加一",
                     "type": "integer"
                 }
             }
         },
-        "models.RegistrationRequest": {
+        "models.SeriesRequest": {
             "type": "object",
             "required": [
-                "email",
-                "emergency_contact",
-                "emergency_phone",
-                "id_card",
-                "name",
-                "phone"
+                "capacity",
+                "location",
+                "start_at",
+                "tags",
+                "title"
             ],
             "properties": {
-                "email": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 30
+                },
+                "category_ids": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "description": {
                     "type": "string",
-                    "example": "zhangsan@example.com"
+                    "example": "沿海岸线巡查并清理垃圾"
                 },
-                "emergency_contact": {
+                "duration_minutes": {
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0,
+                    "example": 180
+                },
+                "effective_from": {
+                    "description": "EffectiveFrom 仅用于更新：此时间之后开始、且未单独修改过的场次按新模板和规则调整，为空时从当前时间起生效",
                     "type": "string",
-                    "example": "李四"
+                    "example": "2025-07-01T00:00:00+08:00"
                 },
-                "emergency_phone": {
+                "location": {
                     "type": "string",
-                    "example": "13900139000"
+                    "example": "青岛市第一海水浴场"
                 },
-                "id_card": {
+                "max_age": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "min_age": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 18
+                },
+                "organizer_email": {
                     "type": "string",
-                    "example": "110101199001011234"
+                    "example": "organizer@example.com"
                 },
-                "name": {
+                "organizer_name": {
                     "type": "string",
-                    "example": "张三"
+                    "example": "王老师"
                 },
-                "phone": {
+                "organizer_phone": {
                     "type": "string",
-                    "example": "13800138000"
+                    "example": "13900139000"
+                },
+                "recurrence": {
+                    "$ref": "#/definitions/models.Recurrence"
+                },
+                "start_at": {
+                    "type": "string",
+                    "example": "2025-06-07T09:00:00+08:00"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "巡护"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "周六海滩巡护"
                 }
             }
         },
-        "models.RegistrationsResponse": {
+        "models.StatusUpdateRequest": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "registrations": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Registration"
-                    }
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "models.Response": {
+        "models.Tag": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "message": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "models.StatusUpdateRequest": {
+        "models.TagUsage": {
+            "type": "object",
+            "properties": {
+                "activities": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "潜水"
+                }
+            }
+        },
+        "models.Trash": {
+            "type": "object",
+            "properties": {
+                "activities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Activity"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                },
+                "volunteers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Volunteer"
+                    }
+                }
+            }
+        },
+        "models.UpdateLanguageRequest": {
             "type": "object",
             "required": [
-                "status"
+                "language"
             ],
             "properties": {
-                "status": {
-                    "type": "string"
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "zh",
+                        "en"
+                    ],
+                    "example": "en"
+                }
+            }
+        },
+        "models.UpdatePermissionsRequest": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "pii:view"
+                    ]
                 }
             }
         },
@@ -1442,10 +4664,19 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "password": {
+                "language": {
+                    "description": "界面语言偏好：zh或en，为空时按Accept-Language协商",
+                    "type": "string"
+                },
+                "permissions": {
+                    "description": "逗号分隔的附加权限，如pii:view,pii:reveal",
                     "type": "string"
                 },
                 "role": {
@@ -1460,17 +4691,10 @@ const docTemplate = `{
                 },
                 "username": {
                     "type": "string"
-                }
-            }
-        },
-        "models.UsersResponse": {
-            "type": "object",
-            "properties": {
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.User"
-                    }
+                },
+                "version": {
+                    "description": "乐观锁版本号，每次更新加一",
+                    "type": "integer"
                 }
             }
         },
@@ -1486,6 +4710,10 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1512,17 +4740,10 @@ const docTemplate = `{
                 },
                 "user_id": {
                     "type": "integer"
-                }
-            }
-        },
-        "models.VolunteersResponse": {
-            "type": "object",
-            "properties": {
-                "volunteers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Volunteer"
-                    }
+                },
+                "version": {
+                    "description": "乐观锁版本号，每次更新加一",
+                    "type": "integer"
                 }
             }
         }
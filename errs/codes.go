@@ -0,0 +1,96 @@
+package errs
+
+// 通用错误
+var (
+	ErrInternal       = New(KindInternal, "INTERNAL_ERROR", "服务器内部错误")
+	ErrInvalidRequest = New(KindValidation, "INVALID_REQUEST", "请求参数无效")
+	ErrInvalidID      = New(KindValidation, "INVALID_ID", "无效的ID参数")
+	ErrRequestTimeout = New(KindTimeout, "REQUEST_TIMEOUT", "请求处理超时")
+	ErrBodyTooLarge   = New(KindPayloadTooLarge, "BODY_TOO_LARGE", "请求体超过大小限制")
+	ErrNotReady       = New(KindUnavailable, "NOT_READY", "服务尚未就绪")
+	ErrRateLimited    = New(KindTooManyRequests, "RATE_LIMITED", "请求过于频繁")
+)
+
+// 并发控制错误
+var (
+	ErrInvalidIfMatch  = New(KindValidation, "INVALID_IF_MATCH", "无效的If-Match请求头")
+	ErrVersionConflict = New(KindPreconditionFailed, "VERSION_CONFLICT", "数据已被其他人修改，请刷新后重试")
+)
+
+// 幂等请求错误
+var (
+	ErrIdempotencyKeyInvalid = New(KindValidation, "INVALID_IDEMPOTENCY_KEY", "无效的Idempotency-Key请求头")
+	ErrIdempotencyKeyReused  = New(KindUnprocessable, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key已用于内容不同的请求")
+	ErrIdempotencyInProgress = New(KindConflict, "IDEMPOTENCY_IN_PROGRESS", "使用相同Idempotency-Key的请求正在处理中")
+)
+
+// 认证与权限错误
+var (
+	ErrTokenMissing      = New(KindUnauthorized, "TOKEN_MISSING", "未提供认证token")
+	ErrTokenMalformed    = New(KindUnauthorized, "TOKEN_MALFORMED", "无效的token格式")
+	ErrTokenInvalid      = New(KindUnauthorized, "TOKEN_INVALID", "无效的token")
+	ErrUserInvalid       = New(KindUnauthorized, "USER_INVALID", "无效的用户")
+	ErrRoleMismatch      = New(KindUnauthorized, "ROLE_MISMATCH", "用户角色验证失败")
+	ErrNotLoggedIn       = New(KindUnauthorized, "NOT_LOGGED_IN", "未登录")
+	ErrUserDisabled      = New(KindForbidden, "USER_DISABLED", "用户已被禁用")
+	ErrAdminRequired     = New(KindForbidden, "ADMIN_REQUIRED", "需要管理员权限")
+	ErrVolunteerRequired = New(KindForbidden, "VOLUNTEER_REQUIRED", "需要志愿者权限")
+	ErrPermissionDenied  = New(KindForbidden, "PERMISSION_DENIED", "缺少所需的附加权限")
+)
+
+// 用户错误
+var (
+	ErrUserNotFound       = New(KindNotFound, "USER_NOT_FOUND", "用户不存在")
+	ErrUsernameTaken      = New(KindConflict, "USERNAME_TAKEN", "用户名已存在")
+	ErrInvalidRole        = New(KindValidation, "INVALID_ROLE", "无效的用户角色")
+	ErrInvalidCredentials = New(KindUnauthorized, "INVALID_CREDENTIALS", "用户名或密码错误")
+	ErrAccountDisabled    = New(KindForbidden, "ACCOUNT_DISABLED", "用户账号已被禁用")
+	ErrWrongPassword      = New(KindValidation, "WRONG_PASSWORD", "旧密码错误")
+	ErrPasswordIncorrect  = New(KindValidation, "PASSWORD_INCORRECT", "密码错误")
+	ErrUnsupportedLang    = New(KindValidation, "UNSUPPORTED_LANGUAGE", "不支持的语言")
+)
+
+// 活动错误
+var (
+	ErrActivityNotFound     = New(KindNotFound, "ACTIVITY_NOT_FOUND", "活动不存在")
+	ErrActivityDateRequired = New(KindValidation, "ACTIVITY_DATE_REQUIRED", "活动日期不能为空")
+	ErrActivityNotOpen      = New(KindConflict, "ACTIVITY_NOT_OPEN", "活动不在报名阶段")
+	ErrActivityFull         = New(KindCapacityFull, "ACTIVITY_FULL", "活动名额已满")
+	ErrActivityHasSignups   = New(KindConflict, "ACTIVITY_HAS_REGISTRATIONS", "活动已有报名，不能改回草稿")
+	ErrAgeBelowMinimum      = New(KindForbidden, "AGE_BELOW_MINIMUM", "未达到活动的最低年龄要求")
+	ErrAgeAboveMaximum      = New(KindForbidden, "AGE_ABOVE_MAXIMUM", "超过活动的最高年龄限制")
+	ErrBirthDateUnknown     = New(KindValidation, "BIRTH_DATE_UNKNOWN", "无法确定报名人出生日期")
+)
+
+// 分类错误
+var (
+	ErrCategoryNotFound  = New(KindNotFound, "CATEGORY_NOT_FOUND", "活动分类不存在")
+	ErrCategoryNameTaken = New(KindConflict, "CATEGORY_NAME_TAKEN", "活动分类名称已存在")
+	ErrUnknownCategory   = New(KindValidation, "UNKNOWN_CATEGORY", "活动包含不存在的分类")
+)
+
+// 活动系列错误
+var (
+	ErrSeriesNotFound    = New(KindNotFound, "SERIES_NOT_FOUND", "活动系列不存在")
+	ErrSeriesCancelled   = New(KindConflict, "SERIES_CANCELLED", "活动系列已取消")
+	ErrRecurrenceInvalid = New(KindValidation, "RECURRENCE_INVALID", "重复规则无法产生任何场次")
+)
+
+// 志愿者错误
+var (
+	ErrVolunteerNotFound = New(KindNotFound, "VOLUNTEER_NOT_FOUND", "未找到志愿者信息")
+)
+
+// 报名错误
+var (
+	ErrRegistrationNotFound = New(KindNotFound, "REGISTRATION_NOT_FOUND", "未找到报名记录")
+	ErrAlreadyRegistered    = New(KindConflict, "ALREADY_REGISTERED", "已经报名过该活动")
+	ErrDocumentRegistered   = New(KindConflict, "DOCUMENT_ALREADY_REGISTERED", "该证件已报名过该活动")
+)
+
+// 回收站错误
+var (
+	ErrTrashTypeInvalid  = New(KindValidation, "TRASH_TYPE_INVALID", "无效的回收站类型")
+	ErrTrashItemNotFound = New(KindNotFound, "TRASH_ITEM_NOT_FOUND", "回收站中不存在该记录")
+	ErrTrashOwnerDeleted = New(KindConflict, "TRASH_OWNER_DELETED", "所属用户仍在回收站中，请先恢复用户")
+)
@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"net/http"
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/i18n"
 	"seaguard-admin-backend/models"
 	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,25 +32,32 @@ func NewActivityHandler(service service.ActivityService) *ActivityHandler {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {object} models.ActivitiesResponse "活动列表"
+// @Param category query int false "只返回属于该分类ID的活动"
+// @Param tag query string false "只返回带有该标签的活动"
+// @Param If-None-Match header string false "上次响应的ETag，列表未变化时返回304"
+// @Param If-Modified-Since header string false "上次响应的Last-Modified，列表此后未变化时返回304"
+// @Success 200 {object} models.Response{data=[]models.Activity} "活动列表，附带ETag和Last-Modified响应头"
+// @Success 304 "列表未变化"
+// @Failure 400 {object} models.Response "无效的分类ID"
 // @Failure 403 {object} models.Response "无权限访问"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /admin/activities [get]
 func (h *ActivityHandler) ListActivitiesForAdmin(c *gin.Context) {
-    activities, err := h.service.GetAllActivities()
-    if err != nil {
-        c.JSON(500, models.ActivitiesResponse{
-            Code:    500,
-            Message: "获取活动列表失败",
-            Data:    nil,
-        })
-        return
-    }
-    c.JSON(200, models.ActivitiesResponse{
-        Code:    200,
-        Message: "获取活动列表成功",
-        Data:    activities,
-    })
+	filter, err := activityFilter(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	list, err := h.service.GetAllActivities(c.Request.Context(), filter)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	if notModified(c, list.ETag, list.LastModified) {
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgActivityListOK, list.Activities)
 }
 
 // ListAvailableActivities godoc
@@ -55,25 +67,74 @@ func (h *ActivityHandler) ListActivitiesForAdmin(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {object} models.ActivitiesResponse "活动列表"
+// @Param category query int false "只返回属于该分类ID的活动"
+// @Param tag query string false "只返回带有该标签的活动"
+// @Param If-None-Match header string false "上次响应的ETag，列表未变化时返回304"
+// @Param If-Modified-Since header string false "上次响应的Last-Modified，列表此后未变化时返回304"
+// @Success 200 {object} models.Response{data=[]models.Activity} "活动列表，按开始时间排序，附带ETag和Last-Modified响应头"
+// @Success 304 "列表未变化"
+// @Failure 400 {object} models.Response "无效的分类ID"
 // @Failure 403 {object} models.Response "无权限访问"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /activities [get]
 func (h *ActivityHandler) ListAvailableActivities(c *gin.Context) {
-    activities, err := h.service.GetAvailableActivities()
-    if err != nil {
-        c.JSON(500, models.ActivitiesResponse{
-            Code:    500,
-            Message: "获取活动列表失败",
-            Data:    nil,
-        })
-        return
-    }
-    c.JSON(200, models.ActivitiesResponse{
-        Code:    200,
-        Message: "获取可报名活动列表成功",
-        Data:    activities,
-    })
+	filter, err := activityFilter(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	list, err := h.service.GetAvailableActivities(c.Request.Context(), filter)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	if notModified(c, list.ETag, list.LastModified) {
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgAvailableActivityOK, list.Activities)
+}
+
+// GetActivity godoc
+// @Summary 获取活动详情
+// @Description 获取活动信息及剩余名额、待审核报名数（pending_count）、当前用户的报名状态和签到时间窗口；草稿活动仅管理员可见
+// @Tags 活动管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "活动ID"
+// @Param If-None-Match header string false "上次响应的ETag，详情未变化时返回304"
+// @Success 200 {object} models.Response{data=models.ActivityDetail} "活动详情，ETag响应头为当前用户所见内容的摘要，修改活动时的If-Match请使用version字段"
+// @Success 304 "详情未变化"
+// @Failure 400 {object} models.Response "无效的ID参数"
+// @Failure 401 {object} models.Response "未登录"
+// @Failure 404 {object} models.Response "活动不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /activities/{id} [get]
+func (h *ActivityHandler) GetActivity(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	detail, err := h.service.GetActivityDetail(c.Request.Context(), id, userID, c.GetString("userRole") == "admin")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	// 不同用户看到的报名状态不同，共享缓存需按Authorization区分
+	c.Header("Vary", "Authorization")
+	if notModified(c, detail.ETag, time.Time{}) {
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgActivityDetailOK, detail)
 }
 
 // CreateActivity godoc
@@ -83,99 +144,81 @@ func (h *ActivityHandler) ListAvailableActivities(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param activity body models.Activity true "活动信息"
-// @Success 201 {object} models.Activity "创建成功的活动信息"
-// @Failure 400 {object} models.Response "请求参数无效"
+// @Param activity body models.ActivityRequest true "活动信息"
+// @Param Idempotency-Key header string false "幂等Key，网络重试时使用相同的值，避免重复创建"
+// @Success 201 {object} models.Response{data=models.Activity} "创建成功的活动信息"
+// @Failure 400 {object} models.Response "请求参数无效或分类不存在"
 // @Failure 403 {object} models.Response "无权限访问"
+// @Failure 409 {object} models.Response "使用相同Idempotency-Key的请求正在处理中"
+// @Failure 422 {object} models.Response "Idempotency-Key已用于内容不同的请求"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /activities [post]
 func (h *ActivityHandler) CreateActivity(c *gin.Context) {
-	var activity models.Activity
-if err := c.ShouldBindJSON(&activity); err != nil {
-    c.JSON(400, models.Response{
-        Error: "请求数据无效：" + err.Error(),
-    })
-    return
-}
-
-// 验证活动日期
-if activity.Date.IsZero() {
-    c.JSON(400, models.Response{
-        Error: "活动日期不能为空",
-    })
-    return
-}
+	var req models.ActivityRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
 
-if err := h.service.CreateActivity(&activity); err != nil {
-    c.JSON(500, models.Response{
-        Error: "创建活动失败：" + err.Error(),
-    })
-    return
-}
+	activity := newActivityFromRequest(&req)
+	if err := h.service.CreateActivity(c.Request.Context(), activity, req.Draft); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
 
-c.JSON(201, models.Response{
-    Message: "创建活动成功",
-})
+	utils.RespondOK(c, http.StatusCreated, i18n.MsgActivityCreated, activity)
 }
 
 // UpdateActivity godoc
 // @Summary 更新活动信息
-// @Description 更新指定ID的活动信息（需要管理员权限）
+// @Description 更新指定ID的活动信息；活动系列中的场次单独修改后，修改系列时不再随之调整（需要管理员权限）
 // @Tags 活动管理
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "活动ID"
-// @Param activity body models.Activity true "活动信息"
-// @Success 200 {object} models.Activity "更新后的活动信息"
-// @Failure 400 {object} models.Response "无效的ID参数或请求数据"
+// @Param If-Match header string false "活动当前版本号（即version字段或上次响应的ETag），不一致时返回412"
+// @Param activity body models.ActivityRequest true "活动信息"
+// @Success 200 {object} models.Response{data=models.Activity} "更新后的活动信息，ETag响应头为新版本号"
+// @Failure 400 {object} models.Response "无效的ID参数或请求数据，或分类不存在"
 // @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "活动不存在"
+// @Failure 409 {object} models.Response "活动已有报名，不能改回草稿"
+// @Failure 412 {object} models.Response "活动已被其他人修改"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /activities/{id} [put]
 func (h *ActivityHandler) UpdateActivity(c *gin.Context) {
-	var activity models.Activity
-if err := c.ShouldBindJSON(&activity); err != nil {
-    c.JSON(400, models.Response{
-        Error: "请求数据无效：" + err.Error(),
-    })
-    return
-}
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
 
-// 验证活动日期
-if activity.Date.IsZero() {
-    c.JSON(400, models.Response{
-        Error: "活动日期不能为空",
-    })
-    return
-}
+	var req models.ActivityRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
 
-id := uint(0)
-if idParam := c.Param("id"); idParam != "" {
-    if n, err := strconv.ParseUint(idParam, 10, 32); err == nil {
-        id = uint(n)
-    } else {
-        c.JSON(400, models.Response{
-            Error: "无效的ID参数",
-        })
-        return
-    }
-}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
 
-if err := h.service.UpdateActivity(id, &activity); err != nil {
-    c.JSON(500, models.Response{
-        Error: "更新活动失败：" + err.Error(),
-    })
-    return
-}
+	activity, err := h.service.UpdateActivity(c.Request.Context(), id, newActivityFromRequest(&req), req.Draft, version)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
 
-c.JSON(200, models.Response{
-    Message: "更新活动成功",
-})
+	setETag(c, activity.Version)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgActivityUpdated, activity)
 }
 
 // DeleteActivity godoc
 // @Summary 删除活动
-// @Description 删除指定ID的活动（需要管理员权限）
+// @Description 将指定ID的活动移入回收站，可通过回收站恢复或彻底删除；删除系列中的场次时该日期加入系列的跳过列表（需要管理员权限）
 // @Tags 活动管理
 // @Accept json
 // @Produce json
@@ -184,29 +227,63 @@ c.JSON(200, models.Response{
 // @Success 200 {object} models.Response "活动删除成功"
 // @Failure 400 {object} models.Response "无效的ID参数"
 // @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "活动不存在"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /activities/{id} [delete]
 func (h *ActivityHandler) DeleteActivity(c *gin.Context) {
-id := uint(0)
-if idParam := c.Param("id"); idParam != "" {
-    if n, err := strconv.ParseUint(idParam, 10, 32); err == nil {
-        id = uint(n)
-    } else {
-        c.JSON(400, models.Response{
-            Error: "无效的ID参数",
-        })
-        return
-    }
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if err := h.service.DeleteActivity(c.Request.Context(), id); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgActivityDeleted, nil)
 }
 
-if err := h.service.DeleteActivity(id); err != nil {
-    c.JSON(500, models.Response{
-        Error: "删除活动失败：" + err.Error(),
-    })
-    return
+// activityFilter 解析活动列表的分类和标签筛选参数
+func activityFilter(c *gin.Context) (models.ActivityFilter, error) {
+	filter := models.ActivityFilter{Tag: models.NormalizeTag(c.Query("tag"))}
+	if category := c.Query("category"); category != "" {
+		id, err := strconv.ParseUint(category, 10, 32)
+		if err != nil || id == 0 {
+			return filter, errs.ErrInvalidID
+		}
+		filter.CategoryID = uint(id)
+	}
+	return filter, nil
 }
 
-c.JSON(200, models.Response{
-    Message: "活动删除成功",
-})
+// newActivityFromRequest 根据请求构造活动模型，分类只填写ID，标签只填写名称
+func newActivityFromRequest(req *models.ActivityRequest) *models.Activity {
+	categories := make([]models.Category, 0, len(req.CategoryIDs))
+	for _, id := range req.CategoryIDs {
+		categories = append(categories, models.Category{ID: id})
+	}
+	tags := make([]models.Tag, 0, len(req.Tags))
+	for _, name := range req.Tags {
+		tags = append(tags, models.Tag{Name: name})
+	}
+
+	return &models.Activity{
+		Title:       req.Title,
+		Date:        req.Date,
+		EndDate:     req.EndDate,
+		Location:    req.Location,
+		Capacity:    req.Capacity,
+		MinAge:      req.MinAge,
+		MaxAge:      req.MaxAge,
+		Description: req.Description,
+
+		OrganizerName:  req.OrganizerName,
+		OrganizerPhone: req.OrganizerPhone,
+		OrganizerEmail: req.OrganizerEmail,
+
+		Categories: categories,
+		Tags:       tags,
+	}
 }
@@ -0,0 +1,135 @@
+package handlers_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"seaguard-admin-backend/handlers"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils/fieldcrypt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 测试专用密钥，与生产配置无关
+const (
+	testEncryptionKeys = "test:c2VhZ3VhcmQtdGVzdC1maWVsZC1lbmNyeXB0aW9uLWs="
+	testBlindIndexKey  = "c2VhZ3VhcmQtdGVzdC1ibGluZC1pbmRleA=="
+)
+
+// openTestDB 在临时目录中创建与生产环境相同连接参数的SQLite数据库
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	keyring, err := fieldcrypt.NewKeyring(testEncryptionKeys, "", testBlindIndexKey, false)
+	if err != nil {
+		t.Fatalf("创建密钥环失败: %v", err)
+	}
+	fieldcrypt.Setup(keyring)
+
+	dsn := filepath.Join(t.TempDir(), "seaguard.db") + "?_busy_timeout=5000&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开数据库失败: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if err := db.AutoMigrate(&models.Activity{}, &models.Category{}, &models.Tag{}, &models.Registration{}); err != nil {
+		t.Fatalf("迁移表结构失败: %v", err)
+	}
+	return db
+}
+
+// TestGetActivityETagChangesWhenRegistrationApproved 报名通过不改变活动版本号，但详情中的报名状态和待审核数变化，旧ETag不能再得到304
+func TestGetActivityETagChangesWhenRegistrationApproved(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openTestDB(t)
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	actRepo := repository.NewActivityRepository(db, log)
+	regRepo := repository.NewRegistrationRepository(db, log)
+	uow := repository.NewUnitOfWork(db, log)
+	cache := service.NewActivityCache(time.Minute)
+	activityService := service.NewActivityService(actRepo, regRepo, uow, cache, log)
+	registrationService := service.NewRegistrationService(regRepo, actRepo, uow, cache, log)
+	ctx := context.Background()
+
+	activity := &models.Activity{
+		Title:    "海滩清洁",
+		Date:     time.Now().AddDate(0, 0, 7),
+		Status:   models.ActivityStatusOpen,
+		Location: "青岛市第一海水浴场",
+		Capacity: 10,
+	}
+	if err := actRepo.Create(ctx, activity); err != nil {
+		t.Fatalf("创建活动失败: %v", err)
+	}
+	const volunteerID = 1
+	registration := &models.Registration{
+		ActivityID:       activity.ID,
+		Name:             "张三",
+		Phone:            "13800138000",
+		DocumentType:     models.DocumentPassport,
+		IDCard:           "E00000001",
+		EmergencyContact: "李四",
+		EmergencyPhone:   "13800138001",
+	}
+	if err := registrationService.CreateRegistration(ctx, volunteerID, registration); err != nil {
+		t.Fatalf("报名失败: %v", err)
+	}
+
+	// 以固定的志愿者身份访问，省略认证中间件
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uint(volunteerID))
+		c.Set("userRole", "volunteer")
+	})
+	router.GET("/activities/:id", handlers.NewActivityHandler(activityService).GetActivity)
+	get := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/activities/"+strconv.FormatUint(uint64(activity.ID), 10), nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("首次请求返回%d，ETag为%q", first.Code, etag)
+	}
+	if vary := first.Header().Get("Vary"); vary != "Authorization" {
+		t.Errorf("Vary响应头为%q，期望Authorization", vary)
+	}
+	if w := get(etag); w.Code != http.StatusNotModified {
+		t.Fatalf("内容未变化时返回%d，期望304", w.Code)
+	}
+
+	if _, err := registrationService.UpdateRegistrationStatus(ctx, registration.ID, models.RegistrationApproved, 0); err != nil {
+		t.Fatalf("审核报名失败: %v", err)
+	}
+	w := get(etag)
+	if w.Code != http.StatusOK {
+		t.Fatalf("报名通过后使用旧ETag返回%d，期望200", w.Code)
+	}
+	if w.Header().Get("ETag") == etag {
+		t.Error("报名通过后ETag未变化")
+	}
+}
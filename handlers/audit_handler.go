@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler 审计日志处理器结构
+type AuditHandler struct {
+	service service.AuditService
+}
+
+// NewAuditHandler 创建审计日志处理器实例
+func NewAuditHandler(service service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+	}
+}
+
+// ListAuditLogs godoc
+// @Summary 获取审计日志
+// @Description 按时间倒序获取最近的审计日志（需要管理员权限）
+// @Tags 审计日志
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param limit query int false "返回条数，默认100，最大500"
+// @Success 200 {object} models.Response{data=[]models.AuditLog} "审计日志列表"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/audit-logs [get]
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	logs, err := h.service.ListRecent(c.Request.Context(), limit)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgAuditLogListOK, logs)
+}
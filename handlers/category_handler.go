@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"net/http"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryHandler 活动分类与标签处理器结构
+type CategoryHandler struct {
+	service service.CategoryService
+}
+
+// NewCategoryHandler 创建活动分类处理器实例
+func NewCategoryHandler(service service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		service: service,
+	}
+}
+
+// ListCategories godoc
+// @Summary 获取活动分类
+// @Description 获取所有活动分类，可用于筛选活动列表
+// @Tags 活动分类
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=[]models.Category} "分类列表，按名称排序"
+// @Failure 401 {object} models.Response "未登录"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /categories [get]
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	categories, err := h.service.ListCategories(c.Request.Context())
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgCategoryListOK, categories)
+}
+
+// CreateCategory godoc
+// @Summary 创建活动分类
+// @Description 创建一个新的活动分类（需要管理员权限）
+// @Tags 活动分类
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param category body models.CategoryRequest true "分类信息"
+// @Param Idempotency-Key header string false "幂等Key，网络重试时使用相同的值，避免重复创建"
+// @Success 201 {object} models.Response{data=models.Category} "创建成功的分类"
+// @Failure 400 {object} models.Response "请求参数无效"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 409 {object} models.Response "分类名称已存在，或使用相同Idempotency-Key的请求正在处理中"
+// @Failure 422 {object} models.Response "Idempotency-Key已用于内容不同的请求"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /categories [post]
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req models.CategoryRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	category, err := h.service.CreateCategory(c.Request.Context(), &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusCreated, i18n.MsgCategoryCreated, category)
+}
+
+// UpdateCategory godoc
+// @Summary 更新活动分类
+// @Description 更新指定ID的分类名称和说明（需要管理员权限）
+// @Tags 活动分类
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "分类ID"
+// @Param category body models.CategoryRequest true "分类信息"
+// @Success 200 {object} models.Response{data=models.Category} "更新后的分类"
+// @Failure 400 {object} models.Response "无效的ID参数或请求数据"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "分类不存在"
+// @Failure 409 {object} models.Response "分类名称已存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	var req models.CategoryRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	category, err := h.service.UpdateCategory(c.Request.Context(), id, &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgCategoryUpdated, category)
+}
+
+// DeleteCategory godoc
+// @Summary 删除活动分类
+// @Description 删除指定ID的分类，已关联的活动保留但不再属于该分类（需要管理员权限）
+// @Tags 活动分类
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "分类ID"
+// @Success 200 {object} models.Response "分类删除成功"
+// @Failure 400 {object} models.Response "无效的ID参数"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "分类不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if err := h.service.DeleteCategory(c.Request.Context(), id); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgCategoryDeleted, nil)
+}
+
+// CategoryStats godoc
+// @Summary 获取分类统计
+// @Description 按分类统计已发布活动的数量、名额、已占用名额和已通过的报名数，不含草稿和已删除的活动；属于多个分类的活动在每个分类中都计入（需要管理员权限）
+// @Tags 活动分类
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=[]models.CategoryStats} "分类统计，按名称排序"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/categories/stats [get]
+func (h *CategoryHandler) CategoryStats(c *gin.Context) {
+	stats, err := h.service.CategoryStats(c.Request.Context())
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgCategoryStatsOK, stats)
+}
+
+// ListTags godoc
+// @Summary 获取活动标签
+// @Description 获取已发布活动使用的标签及使用次数，按使用次数降序排列
+// @Tags 活动分类
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=[]models.TagUsage} "标签列表"
+// @Failure 401 {object} models.Response "未登录"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /tags [get]
+func (h *CategoryHandler) ListTags(c *gin.Context) {
+	tags, err := h.service.ListTags(c.Request.Context())
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgTagListOK, tags)
+}
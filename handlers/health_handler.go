@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler 健康检查处理器，供容器编排系统探测，不经过认证
+type HealthHandler struct {
+	service service.HealthService
+}
+
+// NewHealthHandler 创建健康检查处理器实例
+func NewHealthHandler(service service.HealthService) *HealthHandler {
+	return &HealthHandler{
+		service: service,
+	}
+}
+
+// Healthz 存活探针，进程能处理请求即返回200
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	utils.RespondOK(c, http.StatusOK, i18n.MsgHealthy, nil)
+}
+
+// Readyz 就绪探针，数据库可用、表结构已迁移且后台任务在运行且未停滞时返回200，否则返回503及各检查项结果
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	readiness := h.service.Ready(c.Request.Context())
+	if !readiness.Ready {
+		utils.RespondErrorWithData(c, errs.ErrNotReady, readiness)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgReady, readiness)
+}
+
+// Version 返回构建提交、构建时间和表结构版本
+func (h *HealthHandler) Version(c *gin.Context) {
+	utils.RespondOK(c, http.StatusOK, i18n.MsgBuildInfoOK, h.service.BuildInfo())
+}
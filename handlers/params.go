@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"seaguard-admin-backend/errs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseID 解析路径中的ID参数
+func parseID(c *gin.Context, name string) (uint, error) {
+	n, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, errs.ErrInvalidID
+	}
+	return uint(n), nil
+}
+
+// currentUserID 获取认证中间件写入的当前用户ID
+func currentUserID(c *gin.Context) (uint, error) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		return 0, errs.ErrNotLoggedIn
+	}
+	return userID.(uint), nil
+}
+
+// bindJSON 解析并校验JSON请求体
+func bindJSON(c *gin.Context, obj interface{}) error {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return errs.ErrBodyTooLarge.Wrap(err)
+		}
+		return errs.ErrInvalidRequest.Wrap(err)
+	}
+	return nil
+}
+
+// ifMatchVersion 解析If-Match请求头中的资源版本号，未提供或为"*"时返回0表示不校验
+func ifMatchVersion(c *gin.Context) (uint, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	n, err := strconv.ParseUint(tag, 10, 32)
+	if err != nil || n == 0 {
+		return 0, errs.ErrInvalidIfMatch
+	}
+	return uint(n), nil
+}
+
+// setETag 以资源版本号作为响应的ETag
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", versionETag(version))
+}
+
+// versionETag 返回资源版本号对应的ETag
+func versionETag(version uint) string {
+	return strconv.Quote(strconv.FormatUint(uint64(version), 10))
+}
+
+// notModified 设置ETag、Last-Modified响应头，并按If-None-Match（优先）或If-Modified-Since判断客户端缓存是否仍然有效，
+// 有效时返回304并返回true；响应要求客户端每次使用缓存前重新验证
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if header := c.GetHeader("If-None-Match"); header != "" {
+		if !etagMatches(header, etag) {
+			return false
+		}
+	} else {
+		since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+		if err != nil || lastModified.IsZero() || lastModified.Truncate(time.Second).After(since) {
+			return false
+		}
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// etagMatches 按弱比较判断If-None-Match中是否包含etag
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
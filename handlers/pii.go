@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"seaguard-admin-backend/middleware"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// canViewPII 判断当前用户能否查看指定用户的完整个人信息：本人或拥有pii:view权限
+func canViewPII(c *gin.Context, ownerID uint) bool {
+	if ownerID != 0 && ownerID == c.GetUint("userID") {
+		return true
+	}
+	return middleware.HasPermission(c, models.PermissionPIIView)
+}
+
+// maskRegistration 返回脱敏后的报名记录副本
+func maskRegistration(r models.Registration) models.Registration {
+	r.Phone = utils.MaskPhone(r.Phone)
+	r.IDCard = utils.MaskIDCard(r.IDCard)
+	r.Email = utils.MaskEmail(r.Email)
+	r.EmergencyContact = utils.MaskName(r.EmergencyContact)
+	r.EmergencyPhone = utils.MaskPhone(r.EmergencyPhone)
+	r.BirthDate = nil
+	return r
+}
+
+// maskVolunteer 返回脱敏后的志愿者信息副本
+func maskVolunteer(v models.Volunteer) models.Volunteer {
+	v.Phone = utils.MaskPhone(v.Phone)
+	v.Email = utils.MaskEmail(v.Email)
+	v.Address = utils.MaskAddress(v.Address)
+	return v
+}
+
+// presentRegistration 按当前用户权限输出报名记录
+func presentRegistration(c *gin.Context, r models.Registration) models.Registration {
+	if canViewPII(c, r.UserID) {
+		return r
+	}
+	return maskRegistration(r)
+}
+
+// presentRegistrations 按当前用户权限输出报名记录列表
+func presentRegistrations(c *gin.Context, registrations []models.Registration) []models.Registration {
+	result := make([]models.Registration, 0, len(registrations))
+	for _, r := range registrations {
+		result = append(result, presentRegistration(c, r))
+	}
+	return result
+}
+
+// presentVolunteer 按当前用户权限输出志愿者信息
+func presentVolunteer(c *gin.Context, v models.Volunteer) models.Volunteer {
+	if canViewPII(c, v.UserID) {
+		return v
+	}
+	return maskVolunteer(v)
+}
+
+// presentVolunteers 按当前用户权限输出志愿者列表
+func presentVolunteers(c *gin.Context, volunteers []models.Volunteer) []models.Volunteer {
+	result := make([]models.Volunteer, 0, len(volunteers))
+	for _, v := range volunteers {
+		result = append(result, presentVolunteer(c, v))
+	}
+	return result
+}
+
+// recordAudit 以当前用户为操作人记录审计日志
+func recordAudit(c *gin.Context, audit service.AuditService, action, targetType string, targetID uint, reason string) error {
+	return audit.Record(c.Request.Context(), &models.AuditLog{
+		ActorID:    c.GetUint("userID"),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+		IP:         c.ClientIP(),
+	})
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyHandler 个人数据处理器结构
+type PrivacyHandler struct {
+	service service.PrivacyService
+	audit   service.AuditService
+}
+
+// NewPrivacyHandler 创建个人数据处理器实例
+func NewPrivacyHandler(service service.PrivacyService, audit service.AuditService) *PrivacyHandler {
+	return &PrivacyHandler{
+		service: service,
+		audit:   audit,
+	}
+}
+
+// ExportMyData godoc
+// @Summary 导出个人数据
+// @Description 导出当前用户的账号信息、志愿者档案、报名记录和服务时长。format=zip时以ZIP附件形式下载
+// @Tags 志愿者
+// @Accept json
+// @Produce json,application/zip
+// @Security ApiKeyAuth
+// @Param format query string false "导出格式" Enums(json, zip) default(json)
+// @Success 200 {object} models.Response{data=models.PersonalDataExport} "个人数据"
+// @Failure 400 {object} models.Response "不支持的导出格式"
+// @Failure 401 {object} models.Response "未登录"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /volunteer/my-data [get]
+func (h *PrivacyHandler) ExportMyData(c *gin.Context) {
+	userID, err := currentUserID(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "zip" {
+		utils.RespondError(c, errs.ErrInvalidRequest)
+		return
+	}
+
+	export, err := h.service.ExportUserData(c.Request.Context(), userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if err := recordAudit(c, h.audit, models.AuditActionDataExport, "user", userID, format); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if format == "json" {
+		utils.RespondOK(c, http.StatusOK, i18n.MsgDataExported, export)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="seaguard-my-data-%d.zip"`, userID))
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+	if err := writeExportZip(c.Writer, export); err != nil {
+		// 响应头已发送，只能中断连接
+		c.Error(err)
+		c.Abort()
+	}
+}
+
+// ForgetMe godoc
+// @Summary 注销并匿名化个人数据
+// @Description 验证密码后清除当前用户的个人信息并停用账号，报名状态与服务时长等统计数据会以匿名形式保留
+// @Tags 志愿者
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.ForgetMeRequest true "当前密码"
+// @Success 200 {object} models.Response "个人数据已匿名化"
+// @Failure 400 {object} models.Response "请求参数无效或密码错误"
+// @Failure 401 {object} models.Response "未登录"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /volunteer/forget [post]
+func (h *PrivacyHandler) ForgetMe(c *gin.Context) {
+	userID, err := currentUserID(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	var req models.ForgetMeRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if err := h.service.ForgetMe(c.Request.Context(), userID, req.Password); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if err := recordAudit(c, h.audit, models.AuditActionUserAnonymize, "user", userID, "self-service"); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgUserAnonymized, nil)
+}
+
+// AnonymizeUser godoc
+// @Summary 匿名化用户
+// @Description 管理员代为执行用户的被遗忘权请求，清除个人信息并停用账号，保留统计数据
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "用户ID"
+// @Success 200 {object} models.Response "个人数据已匿名化"
+// @Failure 400 {object} models.Response "无效的用户ID"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "用户不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /users/{id}/anonymize [post]
+func (h *PrivacyHandler) AnonymizeUser(c *gin.Context) {
+	userID, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if err := h.service.AnonymizeUser(c.Request.Context(), userID); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if err := recordAudit(c, h.audit, models.AuditActionUserAnonymize, "user", userID, "admin"); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgUserAnonymized, nil)
+}
+
+// writeExportZip 将导出数据按类别写入ZIP压缩包
+func writeExportZip(w http.ResponseWriter, export *models.PersonalDataExport) error {
+	archive := zip.NewWriter(w)
+	files := map[string]interface{}{
+		"user.json":          export.User,
+		"volunteer.json":     export.Volunteer,
+		"registrations.json": export.Registrations,
+		"summary.json": gin.H{
+			"exported_at": export.ExportedAt,
+			"total_hours": export.TotalHours,
+		},
+	}
+	for name, content := range files {
+		f, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"net/http"
+	"seaguard-admin-backend/i18n"
 	"seaguard-admin-backend/models"
 	"seaguard-admin-backend/service"
-	"strconv"
+	"seaguard-admin-backend/utils"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,46 +14,44 @@ import (
 // RegistrationHandler 报名记录处理器结构
 type RegistrationHandler struct {
 	service service.RegistrationService
+	audit   service.AuditService
 }
 
 // NewRegistrationHandler 创建报名记录处理器实例
-func NewRegistrationHandler(service service.RegistrationService) *RegistrationHandler {
+func NewRegistrationHandler(service service.RegistrationService, audit service.AuditService) *RegistrationHandler {
 	return &RegistrationHandler{
 		service: service,
+		audit:   audit,
 	}
 }
 
 // ListActivityRegistrations godoc
 // @Summary 获取活动报名列表
-// @Description 获取指定活动的所有报名记录（需要志愿者权限）
+// @Description 获取指定活动的所有报名记录（需要志愿者权限）。证件号码、手机号等个人信息默认脱敏，拥有pii:view权限时返回完整信息
 // @Tags 报名管理
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "活动ID"
-// @Success 200 {object} models.RegistrationsResponse "报名记录列表"
+// @Success 200 {object} models.Response{data=[]models.Registration} "报名记录列表"
 // @Failure 400 {object} models.Response "无效的活动ID"
 // @Failure 403 {object} models.Response "无权限访问"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /activities/{id}/registrations [get]
 func (h *RegistrationHandler) ListActivityRegistrations(c *gin.Context) {
-	id := uint(0)
-	if idParam := c.Param("id"); idParam != "" {
-		if n, err := strconv.ParseUint(idParam, 10, 32); err == nil {
-			id = uint(n)
-		} else {
-			c.JSON(400, gin.H{"error": "无效的活动ID参数"})
-			return
-		}
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
 	}
 
-	registrations, err := h.service.GetActivityRegistrations(id)
+	registrations, err := h.service.GetActivityRegistrations(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(500, gin.H{"error": "获取报名列表失败"})
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(200, registrations)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgRegistrationListOK, presentRegistrations(c, registrations))
 }
 
 // UpdateRegistrationStatus godoc
@@ -61,38 +62,42 @@ func (h *RegistrationHandler) ListActivityRegistrations(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "报名ID"
-// @Param status body models.StatusUpdateRequest true "状态信息（可选值：pending待审核、approved已通过、rejected已拒绝）"
+// @Param If-Match header string false "报名记录当前版本号（即version字段或上次响应的ETag），不一致时返回412"
+// @Param status body models.RegistrationStatusRequest true "状态信息（可选值：pending待审核、approved已通过、rejected已拒绝）"
 // @Success 200 {object} models.Response "状态更新成功"
 // @Failure 400 {object} models.Response "无效的报名ID或状态值"
 // @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "未找到报名记录"
+// @Failure 412 {object} models.Response "报名记录已被其他人修改"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /registrations/{id}/status [put]
 func (h *RegistrationHandler) UpdateRegistrationStatus(c *gin.Context) {
-	id := uint(0)
-	if idParam := c.Param("id"); idParam != "" {
-		if n, err := strconv.ParseUint(idParam, 10, 32); err == nil {
-			id = uint(n)
-		} else {
-			c.JSON(400, gin.H{"error": "无效的报名ID参数"})
-			return
-		}
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
 	}
 
-	var statusUpdate struct {
-		Status string `json:"status"`
+	var statusUpdate models.RegistrationStatusRequest
+	if err := bindJSON(c, &statusUpdate); err != nil {
+		utils.RespondError(c, err)
+		return
 	}
 
-	if err := c.ShouldBindJSON(&statusUpdate); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	if err := h.service.UpdateRegistrationStatus(id, statusUpdate.Status); err != nil {
-		c.JSON(500, gin.H{"error": "更新报名状态失败"})
+	registration, err := h.service.UpdateRegistrationStatus(c.Request.Context(), id, statusUpdate.Status, version)
+	if err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"message": "报名状态更新成功"})
+	setETag(c, registration.Version)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgRegistrationStatusOK, nil)
 }
 
 // Register godoc
@@ -104,36 +109,36 @@ func (h *RegistrationHandler) UpdateRegistrationStatus(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param id path int true "活动ID"
 // @Param registration body models.RegistrationRequest true "报名信息"
+// @Param Idempotency-Key header string false "幂等Key，网络重试时使用相同的值，避免重复创建"
 // @Success 201 {object} models.Response "报名成功"
-// @Failure 400 {object} models.Response "无效的活动ID或报名信息"
+// @Failure 400 {object} models.Response "无效的活动ID或报名信息（手机号、身份证号格式错误等，详见errors字段）"
 // @Failure 401 {object} models.Response "未登录"
-// @Failure 403 {object} models.Response "无权限访问"
-// @Failure 409 {object} models.Response "已经报名过该活动"
+// @Failure 403 {object} models.Response "无权限访问或不满足活动年龄要求"
+// @Failure 404 {object} models.Response "活动不存在"
+// @Failure 409 {object} models.Response "已经报名过该活动、活动不在报名阶段、名额已满，或使用相同Idempotency-Key的请求正在处理中"
+// @Failure 422 {object} models.Response "Idempotency-Key已用于内容不同的请求"
+// @Failure 429 {object} models.Response "报名请求过于频繁，Retry-After响应头给出可重试的秒数"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /activities/{id}/register [post]
 func (h *RegistrationHandler) Register(c *gin.Context) {
 	// 获取活动ID
-	activityID := uint(0)
-	if idParam := c.Param("id"); idParam != "" {
-		if n, err := strconv.ParseUint(idParam, 10, 32); err == nil {
-			activityID = uint(n)
-		} else {
-			c.JSON(400, gin.H{"error": "无效的活动ID参数"})
-			return
-		}
+	activityID, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
 	}
 
 	// 获取当前用户ID
-	userID, exists := c.Get("userID")
-	if !exists {
-		c.JSON(401, gin.H{"error": "未登录"})
+	userID, err := currentUserID(c)
+	if err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
 	// 解析请求体
 	var req models.RegistrationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -142,23 +147,26 @@ func (h *RegistrationHandler) Register(c *gin.Context) {
 		ActivityID:       activityID,
 		Name:             req.Name,
 		Phone:            req.Phone,
+		DocumentType:     req.DocumentType,
 		IDCard:           req.IDCard,
+		Gender:           req.Gender,
 		Email:            req.Email,
 		EmergencyContact: req.EmergencyContact,
 		EmergencyPhone:   req.EmergencyPhone,
-		Status:           "pending",
+		Status:           models.RegistrationPending,
+	}
+	if req.BirthDate != "" {
+		// 格式已由datetime校验规则保证
+		birthDate, _ := time.ParseInLocation("2006-01-02", req.BirthDate, time.Local)
+		registration.BirthDate = &birthDate
 	}
 
-	if err := h.service.CreateRegistration(userID.(uint), registration); err != nil {
-		if err.Error() == "already registered" {
-			c.JSON(409, gin.H{"error": "已经报名过该活动"})
-			return
-		}
-		c.JSON(500, gin.H{"error": "报名失败"})
+	if err := h.service.CreateRegistration(c.Request.Context(), userID, registration); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(201, gin.H{"message": "报名成功"})
+	utils.RespondOK(c, http.StatusCreated, i18n.MsgRegistrationCreated, nil)
 }
 
 // GetMyRegistration godoc
@@ -169,7 +177,9 @@ func (h *RegistrationHandler) Register(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "活动ID"
-// @Success 200 {object} models.Registration "报名信息"
+// @Param If-None-Match header string false "上次响应的ETag，报名信息未变化时返回304"
+// @Success 200 {object} models.Response{data=models.Registration} "报名信息，ETag响应头为当前版本号"
+// @Success 304 "报名信息未变化"
 // @Failure 400 {object} models.Response "无效的活动ID"
 // @Failure 401 {object} models.Response "未登录"
 // @Failure 403 {object} models.Response "无权限访问"
@@ -178,33 +188,71 @@ func (h *RegistrationHandler) Register(c *gin.Context) {
 // @Router /activities/{id}/registration [get]
 func (h *RegistrationHandler) GetMyRegistration(c *gin.Context) {
 	// 获取活动ID
-	activityID := uint(0)
-	if idParam := c.Param("id"); idParam != "" {
-		if n, err := strconv.ParseUint(idParam, 10, 32); err == nil {
-			activityID = uint(n)
-		} else {
-			c.JSON(400, gin.H{"error": "无效的活动ID参数"})
-			return
-		}
+	activityID, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
 	}
 
 	// 获取当前用户ID
-	userID, exists := c.Get("userID")
-	if !exists {
-		c.JSON(401, gin.H{"error": "未登录"})
+	userID, err := currentUserID(c)
+	if err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
 	// 查询报名记录
-	registration, err := h.service.GetUserRegistration(userID.(uint), activityID)
+	registration, err := h.service.GetUserRegistration(c.Request.Context(), userID, activityID)
 	if err != nil {
-		if err.Error() == "record not found" {
-			c.JSON(404, gin.H{"error": "未找到报名记录"})
-			return
-		}
-		c.JSON(500, gin.H{"error": "获取报名记录失败"})
+		utils.RespondError(c, err)
+		return
+	}
+
+	if notModified(c, versionETag(registration.Version), registration.UpdatedAt) {
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgRegistrationOK, registration)
+}
+
+// RevealRegistration godoc
+// @Summary 查看报名记录完整个人信息
+// @Description 返回单条报名记录未脱敏的个人信息，需要pii:reveal权限并填写查看原因，每次查看都会记录审计日志
+// @Tags 报名管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "报名ID"
+// @Param request body models.RevealRequest true "查看原因"
+// @Success 200 {object} models.Response{data=models.Registration} "完整报名信息"
+// @Failure 400 {object} models.Response "无效的报名ID或未填写原因"
+// @Failure 403 {object} models.Response "缺少pii:reveal权限"
+// @Failure 404 {object} models.Response "未找到报名记录"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /registrations/{id}/reveal [post]
+func (h *RegistrationHandler) RevealRegistration(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	var req models.RevealRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	registration, err := h.service.GetRegistration(c.Request.Context(), id)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	// 审计日志写入失败时不返回个人信息
+	if err := recordAudit(c, h.audit, models.AuditActionPIIReveal, "registration", id, req.Reason); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(200, registration)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgPIIRevealed, registration)
 }
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionHandler 数据保留策略处理器结构
+type RetentionHandler struct {
+	service service.RetentionService
+}
+
+// NewRetentionHandler 创建数据保留策略处理器实例
+func NewRetentionHandler(service service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		service: service,
+	}
+}
+
+// ListRetentionRules godoc
+// @Summary 获取数据保留规则
+// @Description 获取当前生效的数据保留规则（需要管理员权限）
+// @Tags 数据保留
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=[]models.RetentionRule} "保留规则列表"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Router /admin/retention/rules [get]
+func (h *RetentionHandler) ListRetentionRules(c *gin.Context) {
+	utils.RespondOK(c, http.StatusOK, i18n.MsgRetentionRulesOK, h.service.Rules())
+}
+
+// PreviewRetention godoc
+// @Summary 数据保留试运行
+// @Description 统计各保留规则下已过期的记录数，不修改任何数据（需要管理员权限）
+// @Tags 数据保留
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=models.RetentionReport} "试运行报告"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/retention/report [get]
+func (h *RetentionHandler) PreviewRetention(c *gin.Context) {
+	report, err := h.service.Run(c.Request.Context(), true)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgRetentionReportOK, report)
+}
+
+// RunRetention godoc
+// @Summary 立即执行数据保留策略
+// @Description 立即清除已过保留期的数据，每条实际生效的规则都会写入审计日志（需要管理员权限）
+// @Tags 数据保留
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=models.RetentionReport} "执行报告"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/retention/run [post]
+func (h *RetentionHandler) RunRetention(c *gin.Context) {
+	report, err := h.service.Run(c.Request.Context(), false)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgRetentionRunOK, report)
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"net/http"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeriesHandler 活动系列处理器结构
+type SeriesHandler struct {
+	service service.SeriesService
+}
+
+// NewSeriesHandler 创建活动系列处理器实例
+func NewSeriesHandler(service service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		service: service,
+	}
+}
+
+// ListSeries godoc
+// @Summary 获取活动系列
+// @Description 获取所有重复举行的活动系列（需要管理员权限）
+// @Tags 活动系列
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=[]models.ActivitySeries} "活动系列列表，最近创建的在前"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/series [get]
+func (h *SeriesHandler) ListSeries(c *gin.Context) {
+	series, err := h.service.ListSeries(c.Request.Context())
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgSeriesListOK, series)
+}
+
+// GetSeries godoc
+// @Summary 获取活动系列详情
+// @Description 获取活动系列的模板、重复规则及已生成的全部场次（需要管理员权限）
+// @Tags 活动系列
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "系列ID"
+// @Success 200 {object} models.Response{data=models.SeriesDetail} "系列详情，ETag响应头为当前版本号"
+// @Failure 400 {object} models.Response "无效的ID参数"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "活动系列不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /series/{id} [get]
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	detail, err := h.service.GetSeries(c.Request.Context(), id)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	setETag(c, detail.Version)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgSeriesOK, detail)
+}
+
+// CreateSeries godoc
+// @Summary 创建活动系列
+// @Description 按重复规则创建活动系列，并立即生成滚动窗口内的场次，之后由后台任务定期补齐（需要管理员权限）
+// @Tags 活动系列
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param series body models.SeriesRequest true "系列模板和重复规则"
+// @Param Idempotency-Key header string false "幂等Key，网络重试时使用相同的值，避免重复创建"
+// @Success 201 {object} models.Response{data=models.SeriesDetail} "创建成功的系列及已生成的场次"
+// @Failure 400 {object} models.Response "请求参数无效、重复规则无法产生场次或分类不存在"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 409 {object} models.Response "使用相同Idempotency-Key的请求正在处理中"
+// @Failure 422 {object} models.Response "Idempotency-Key已用于内容不同的请求"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /series [post]
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var req models.SeriesRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	detail, err := h.service.CreateSeries(c.Request.Context(), newSeriesFromRequest(&req))
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	setETag(c, detail.Version)
+	utils.RespondOK(c, http.StatusCreated, i18n.MsgSeriesCreated, detail)
+}
+
+// UpdateSeries godoc
+// @Summary 修改活动系列（此后所有场次）
+// @Description 替换系列模板和重复规则。effective_from之后开始、未单独修改过的场次按新模板调整，不再符合新规则的场次从未有人报名时删除、有报名时标记为已取消；
+// @Description 只修改单个场次请使用PUT /activities/{id}，修改后该场次不再随系列调整（需要管理员权限）
+// @Tags 活动系列
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "系列ID"
+// @Param If-Match header string false "系列当前版本号（即version字段或上次响应的ETag），不一致时返回412"
+// @Param series body models.SeriesRequest true "系列模板和重复规则"
+// @Success 200 {object} models.Response{data=models.SeriesDetail} "更新后的系列及场次，ETag响应头为新版本号"
+// @Failure 400 {object} models.Response "无效的ID参数或请求数据、重复规则无法产生场次或分类不存在"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "活动系列不存在"
+// @Failure 409 {object} models.Response "活动系列已取消"
+// @Failure 412 {object} models.Response "系列或场次已被其他人修改"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /series/{id} [put]
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	var req models.SeriesRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	detail, err := h.service.UpdateSeries(c.Request.Context(), id, newSeriesFromRequest(&req), req.EffectiveFrom, version)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	setETag(c, detail.Version)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgSeriesUpdated, detail)
+}
+
+// CancelSeries godoc
+// @Summary 取消活动系列
+// @Description 停止生成新场次，尚未开始的场次从未有人报名时删除、有报名时标记为已取消；已结束的场次不受影响（需要管理员权限）
+// @Tags 活动系列
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "系列ID"
+// @Success 200 {object} models.Response{data=models.SeriesDetail} "取消后的系列及场次"
+// @Failure 400 {object} models.Response "无效的ID参数"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "活动系列不存在"
+// @Failure 412 {object} models.Response "场次已被其他人修改"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /series/{id}/cancel [post]
+func (h *SeriesHandler) CancelSeries(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	detail, err := h.service.CancelSeries(c.Request.Context(), id)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	setETag(c, detail.Version)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgSeriesCancelled, detail)
+}
+
+// newSeriesFromRequest 根据请求构造活动系列模型
+func newSeriesFromRequest(req *models.SeriesRequest) *models.ActivitySeries {
+	return &models.ActivitySeries{
+		Title:           req.Title,
+		Location:        req.Location,
+		Capacity:        req.Capacity,
+		MinAge:          req.MinAge,
+		MaxAge:          req.MaxAge,
+		Description:     req.Description,
+		OrganizerName:   req.OrganizerName,
+		OrganizerPhone:  req.OrganizerPhone,
+		OrganizerEmail:  req.OrganizerEmail,
+		CategoryIDs:     req.CategoryIDs,
+		Tags:            req.Tags,
+		StartAt:         req.StartAt,
+		DurationMinutes: req.DurationMinutes,
+		Recurrence:      req.Recurrence,
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrashHandler 回收站处理器结构
+type TrashHandler struct {
+	service service.TrashService
+	audit   service.AuditService
+}
+
+// NewTrashHandler 创建回收站处理器实例
+func NewTrashHandler(service service.TrashService, audit service.AuditService) *TrashHandler {
+	return &TrashHandler{
+		service: service,
+		audit:   audit,
+	}
+}
+
+// ListTrash godoc
+// @Summary 获取回收站
+// @Description 获取已删除但尚未彻底删除的用户、志愿者和活动（需要管理员权限）
+// @Tags 回收站
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=models.Trash} "回收站内容"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/trash [get]
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	trash, err := h.service.List(c.Request.Context())
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	trash.Volunteers = presentVolunteers(c, trash.Volunteers)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgTrashListOK, trash)
+}
+
+// RestoreTrashItem godoc
+// @Summary 从回收站恢复
+// @Description 恢复已删除的记录，恢复用户时一并恢复其志愿者信息（需要管理员权限）
+// @Tags 回收站
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type path string true "记录类型" Enums(users, volunteers, activities)
+// @Param id path int true "记录ID"
+// @Success 200 {object} models.Response "恢复成功"
+// @Failure 400 {object} models.Response "无效的类型或ID"
+// @Failure 404 {object} models.Response "回收站中不存在该记录"
+// @Failure 409 {object} models.Response "所属用户仍在回收站中"
+// @Router /admin/trash/{type}/{id}/restore [post]
+func (h *TrashHandler) RestoreTrashItem(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	trashType := c.Param("type")
+	if err := h.service.Restore(c.Request.Context(), trashType, id); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	if err := recordAudit(c, h.audit, models.AuditActionTrashRestore, trashType, id, ""); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgTrashRestored, nil)
+}
+
+// PurgeTrashItem godoc
+// @Summary 彻底删除
+// @Description 彻底删除回收站中的记录，不可恢复。用户：清除其报名记录中的个人信息并删除志愿者信息；活动：删除全部报名记录（需要管理员权限）
+// @Tags 回收站
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type path string true "记录类型" Enums(users, volunteers, activities)
+// @Param id path int true "记录ID"
+// @Success 200 {object} models.Response "彻底删除成功"
+// @Failure 400 {object} models.Response "无效的类型或ID"
+// @Failure 404 {object} models.Response "回收站中不存在该记录"
+// @Router /admin/trash/{type}/{id} [delete]
+func (h *TrashHandler) PurgeTrashItem(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	trashType := c.Param("type")
+	if err := h.service.Purge(c.Request.Context(), trashType, id); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	if err := recordAudit(c, h.audit, models.AuditActionTrashPurge, trashType, id, ""); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgTrashPurged, nil)
+}
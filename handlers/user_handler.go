@@ -1,11 +1,13 @@
 package handlers
 
 import (
-"github.com/gin-gonic/gin"
-"net/http"
-"seaguard-admin-backend/models"
-"seaguard-admin-backend/service"
-"strconv"
+	"net/http"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
@@ -22,8 +24,12 @@ func NewUserHandler(userService *service.UserService) *UserHandler {
 // @Accept json
 // @Produce json
 // @Param request body models.RegisterRequest true "注册信息。当role为volunteer时，需要提供name、phone、email、address等志愿者信息"
+// @Param Idempotency-Key header string false "幂等Key，网络重试时使用相同的值，避免重复注册；未登录时按客户端IP区分"
 // @Success 200 {object} models.Response "注册成功"
-// @Failure 400 {object} models.Response "请求参数无效: 1. 用户名已存在 2. 必填字段缺失 3. role为volunteer时未提供志愿者信息"
+// @Failure 400 {object} models.Response "请求参数无效: 1. 必填字段缺失 2. role为volunteer时未提供志愿者信息 3. 无效的用户角色"
+// @Failure 409 {object} models.Response "用户名已存在，或使用相同Idempotency-Key的请求正在处理中"
+// @Failure 422 {object} models.Response "Idempotency-Key已用于内容不同的请求"
+// @Failure 429 {object} models.Response "注册请求过于频繁，Retry-After响应头给出可重试的秒数"
 // @Example {
 //   "request": {
 //     "username": "zhangsan",
@@ -37,24 +43,18 @@ func NewUserHandler(userService *service.UserService) *UserHandler {
 // }
 // @Router /auth/register [post]
 func (h *UserHandler) Register(c *gin.Context) {
-    var req models.RegisterRequest
-    if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-        return
-    }
-
-    if req.Role != "admin" && req.Role != "volunteer" {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "无效的用户角色"})
-        return
-    }
-
-    err := h.userService.Register(&req)
-    if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-        return
-    }
-
-    c.JSON(http.StatusOK, gin.H{"message": "注册成功"})
+	var req models.RegisterRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if err := h.userService.Register(c.Request.Context(), &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgUserRegistered, nil)
 }
 
 // @Summary 用户登录
@@ -63,34 +63,31 @@ func (h *UserHandler) Register(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body models.LoginRequest true "登录信息"
-// @Success 200 {object} models.LoginResponse "登录成功"
+// @Success 200 {object} models.Response{data=models.LoginResponse} "登录成功"
 // @Failure 400 {object} models.Response "请求参数无效"
 // @Failure 401 {object} models.Response "用户名或密码错误"
+// @Failure 403 {object} models.Response "用户账号已被禁用"
+// @Failure 429 {object} models.Response "登录尝试过于频繁，Retry-After响应头给出可重试的秒数"
 // @Router /auth/login [post]
 func (h *UserHandler) Login(c *gin.Context) {
-	var req struct {
-		Username string `json:"username" binding:"required"`
-		Password string `json:"password" binding:"required"`
-	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数无效"})
+	var req models.LoginRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	user, token, err := h.userService.Login(req.Username, req.Password)
+	user, token, err := h.userService.Login(c.Request.Context(), req.Username, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "登录成功",
-		"token":   token,
-		"user": gin.H{
-			"id":       user.ID,
-			"username": user.Username,
-			"role":     user.Role,
+	utils.RespondOK(c, http.StatusOK, i18n.MsgLoginOK, models.LoginResponse{
+		Token: token,
+		User: models.LoginUser{
+			ID:       user.ID,
+			Username: user.Username,
+			Role:     user.Role,
 		},
 	})
 }
@@ -106,23 +103,47 @@ func (h *UserHandler) Login(c *gin.Context) {
 // @Failure 400 {object} models.Response "请求参数无效或密码错误"
 // @Router /auth/password [put]
 func (h *UserHandler) ChangePassword(c *gin.Context) {
-	var req struct {
-		OldPassword string `json:"old_password" binding:"required"`
-		NewPassword string `json:"new_password" binding:"required"`
+	var req models.ChangePasswordRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数无效"})
+	userID := c.GetUint("userID")
+	if err := h.userService.ChangePassword(c.Request.Context(), userID, req.OldPassword, req.NewPassword); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgPasswordChanged, nil)
+}
+
+// @Summary 更新语言偏好
+// @Description 设置当前用户的界面语言（zh或en），优先于Accept-Language请求头
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.UpdateLanguageRequest true "语言偏好"
+// @Success 200 {object} models.Response "语言偏好更新成功"
+// @Failure 400 {object} models.Response "请求参数无效或不支持的语言"
+// @Router /auth/language [put]
+func (h *UserHandler) UpdateLanguage(c *gin.Context) {
+	var req models.UpdateLanguageRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
 	userID := c.GetUint("userID")
-	if err := h.userService.ChangePassword(userID, req.OldPassword, req.NewPassword); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.userService.UpdateLanguage(c.Request.Context(), userID, req.Language); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "密码修改成功"})
+	// 本次响应即使用新的语言
+	c.Set(i18n.ContextKey, req.Language)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgLanguageUpdated, nil)
 }
 
 // @Summary 获取用户列表
@@ -131,18 +152,18 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {object} models.UsersResponse "用户列表"
+// @Success 200 {object} models.Response{data=[]models.User} "用户列表"
 // @Failure 403 {object} models.Response "无权限访问"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /users [get]
 func (h *UserHandler) ListUsers(c *gin.Context) {
-	users, err := h.userService.ListUsers()
+	users, err := h.userService.ListUsers(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取用户列表失败"})
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"users": users})
+	utils.RespondOK(c, http.StatusOK, i18n.MsgUserListOK, users)
 }
 
 // @Summary 更新用户状态
@@ -152,38 +173,93 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "用户ID"
+// @Param If-Match header string false "用户当前版本号（即version字段或上次响应的ETag），不一致时返回412"
 // @Param request body models.StatusUpdateRequest true "状态信息"
 // @Success 200 {object} models.Response "状态更新成功"
 // @Failure 400 {object} models.Response "无效的用户ID或状态"
 // @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "用户不存在"
+// @Failure 412 {object} models.Response "用户已被其他人修改"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /users/{id}/status [put]
 func (h *UserHandler) UpdateUserStatus(c *gin.Context) {
-	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	userID, err := parseID(c, "id")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的用户ID"})
+		utils.RespondError(c, err)
 		return
 	}
 
-	var req struct {
-		Status string `json:"status" binding:"required"`
+	var req models.StatusUpdateRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数无效"})
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	if err := h.userService.UpdateStatus(uint(userID), req.Status); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	user, err := h.userService.UpdateStatus(c.Request.Context(), userID, req.Status, version)
+	if err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "用户状态更新成功"})
+	setETag(c, user.Version)
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgUserStatusUpdated, nil)
+}
+
+// @Summary 更新用户附加权限
+// @Description 设置指定用户的附加权限（仅管理员可用），可选值：pii:view查看未脱敏个人信息、pii:reveal通过审计接口查看单条完整信息
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "用户ID"
+// @Param If-Match header string false "用户当前版本号（即version字段或上次响应的ETag），不一致时返回412"
+// @Param request body models.UpdatePermissionsRequest true "权限列表"
+// @Success 200 {object} models.Response "权限更新成功"
+// @Failure 400 {object} models.Response "无效的用户ID或权限"
+// @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "用户不存在"
+// @Failure 412 {object} models.Response "用户已被其他人修改"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /users/{id}/permissions [put]
+func (h *UserHandler) UpdateUserPermissions(c *gin.Context) {
+	userID, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	var req models.UpdatePermissionsRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	user, err := h.userService.UpdatePermissions(c.Request.Context(), userID, req.Permissions, version)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	setETag(c, user.Version)
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgPermissionsUpdated, nil)
 }
 
 // @Summary 删除用户
-// @Description 删除指定用户（仅管理员可用）
+// @Description 将指定用户及其志愿者信息移入回收站，可通过回收站恢复或彻底删除（仅管理员可用）
 // @Tags 用户管理
 // @Accept json
 // @Produce json
@@ -192,19 +268,20 @@ func (h *UserHandler) UpdateUserStatus(c *gin.Context) {
 // @Success 200 {object} models.Response "用户删除成功"
 // @Failure 400 {object} models.Response "无效的用户ID"
 // @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "用户不存在"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
-	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	userID, err := parseID(c, "id")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的用户ID"})
+		utils.RespondError(c, err)
 		return
 	}
 
-	if err := h.userService.DeleteUser(uint(userID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.userService.DeleteUser(c.Request.Context(), userID); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "用户删除成功"})
+	utils.RespondOK(c, http.StatusOK, i18n.MsgUserDeleted, nil)
 }
@@ -1,42 +1,47 @@
 package handlers
 
 import (
-	"github.com/gin-gonic/gin"
+	"net/http"
+	"seaguard-admin-backend/i18n"
 	"seaguard-admin-backend/models"
 	"seaguard-admin-backend/service"
-	"strconv"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
 )
 
 // VolunteerHandler 志愿者处理器结构
 type VolunteerHandler struct {
 	service service.VolunteerService
+	audit   service.AuditService
 }
 
 // NewVolunteerHandler 创建志愿者处理器实例
-func NewVolunteerHandler(service service.VolunteerService) *VolunteerHandler {
+func NewVolunteerHandler(service service.VolunteerService, audit service.AuditService) *VolunteerHandler {
 	return &VolunteerHandler{
 		service: service,
+		audit:   audit,
 	}
 }
 
 // ListVolunteers godoc
 // @Summary 获取志愿者列表
-// @Description 获取所有志愿者的列表（需要管理员权限）
+// @Description 获取所有志愿者的列表（需要管理员权限）。手机号、邮箱、地址默认脱敏，拥有pii:view权限时返回完整信息
 // @Tags 志愿者管理
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {object} models.VolunteersResponse "志愿者列表"
+// @Success 200 {object} models.Response{data=[]models.Volunteer} "志愿者列表"
 // @Failure 403 {object} models.Response "无权限访问"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /volunteers [get]
 func (h *VolunteerHandler) ListVolunteers(c *gin.Context) {
-	volunteers, err := h.service.GetAllVolunteers()
+	volunteers, err := h.service.GetAllVolunteers(c.Request.Context())
 	if err != nil {
-		c.JSON(500, gin.H{"error": "获取志愿者列表失败"})
+		utils.RespondError(c, err)
 		return
 	}
-	c.JSON(200, volunteers)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgVolunteerListOK, presentVolunteers(c, volunteers))
 }
 
 // CreateVolunteer godoc
@@ -47,24 +52,27 @@ func (h *VolunteerHandler) ListVolunteers(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param volunteer body models.Volunteer true "志愿者信息"
-// @Success 201 {object} models.Volunteer "创建成功的志愿者信息"
+// @Param Idempotency-Key header string false "幂等Key，网络重试时使用相同的值，避免重复创建"
+// @Success 201 {object} models.Response{data=models.Volunteer} "创建成功的志愿者信息"
 // @Failure 400 {object} models.Response "请求参数无效"
 // @Failure 403 {object} models.Response "无权限访问"
+// @Failure 409 {object} models.Response "使用相同Idempotency-Key的请求正在处理中"
+// @Failure 422 {object} models.Response "Idempotency-Key已用于内容不同的请求"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /volunteers [post]
 func (h *VolunteerHandler) CreateVolunteer(c *gin.Context) {
 	var volunteer models.Volunteer
-	if err := c.ShouldBindJSON(&volunteer); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &volunteer); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	if err := h.service.CreateVolunteer(&volunteer); err != nil {
-		c.JSON(500, gin.H{"error": "创建志愿者失败"})
+	if err := h.service.CreateVolunteer(c.Request.Context(), &volunteer); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(201, volunteer)
+	utils.RespondOK(c, http.StatusCreated, i18n.MsgVolunteerCreated, presentVolunteer(c, volunteer))
 }
 
 // UpdateVolunteer godoc
@@ -75,40 +83,47 @@ func (h *VolunteerHandler) CreateVolunteer(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "志愿者ID"
+// @Param If-Match header string false "志愿者信息当前版本号（即version字段或上次响应的ETag），不一致时返回412"
 // @Param volunteer body models.Volunteer true "志愿者信息"
-// @Success 200 {object} models.Volunteer "更新后的志愿者信息"
+// @Success 200 {object} models.Response{data=models.Volunteer} "更新后的志愿者信息"
 // @Failure 400 {object} models.Response "无效的ID参数或请求数据"
 // @Failure 403 {object} models.Response "无权限访问"
+// @Failure 404 {object} models.Response "未找到志愿者信息"
+// @Failure 412 {object} models.Response "志愿者信息已被其他人修改"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /volunteers/{id} [put]
 func (h *VolunteerHandler) UpdateVolunteer(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
 	var volunteer models.Volunteer
-	if err := c.ShouldBindJSON(&volunteer); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &volunteer); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	id := uint(0)
-	if idParam := c.Param("id"); idParam != "" {
-		if n, err := strconv.ParseUint(idParam, 10, 32); err == nil {
-			id = uint(n)
-		} else {
-			c.JSON(400, gin.H{"error": "无效的ID参数"})
-			return
-		}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
 	}
 
-	if err := h.service.UpdateVolunteer(id, &volunteer); err != nil {
-		c.JSON(500, gin.H{"error": "更新志愿者失败"})
+	updated, err := h.service.UpdateVolunteer(c.Request.Context(), id, &volunteer, version)
+	if err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
-	c.JSON(200, volunteer)
+	setETag(c, updated.Version)
+	utils.RespondOK(c, http.StatusOK, i18n.MsgVolunteerUpdated, presentVolunteer(c, *updated))
 }
 
 // DeleteVolunteer godoc
 // @Summary 删除志愿者
-// @Description 删除指定ID的志愿者（需要管理员权限）
+// @Description 将指定ID的志愿者移入回收站，可通过回收站恢复或彻底删除（需要管理员权限）
 // @Tags 志愿者管理
 // @Accept json
 // @Produce json
@@ -120,21 +135,61 @@ func (h *VolunteerHandler) UpdateVolunteer(c *gin.Context) {
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /volunteers/{id} [delete]
 func (h *VolunteerHandler) DeleteVolunteer(c *gin.Context) {
-	id := uint(0)
-	if idParam := c.Param("id"); idParam != "" {
-		if n, err := strconv.ParseUint(idParam, 10, 32); err == nil {
-			id = uint(n)
-		} else {
-			c.JSON(400, gin.H{"error": "无效的ID参数"})
-			return
-		}
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
 	}
 
-	if err := h.service.DeleteVolunteer(id); err != nil {
-		c.JSON(500, gin.H{"error": "删除志愿者失败"})
+	if err := h.service.DeleteVolunteer(c.Request.Context(), id); err != nil {
+		utils.RespondError(c, err)
 		return
 	}
-c.JSON(200, gin.H{"message": "志愿者删除成功"})
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgVolunteerDeleted, nil)
+}
+
+// RevealVolunteer godoc
+// @Summary 查看志愿者完整个人信息
+// @Description 返回单个志愿者未脱敏的个人信息，需要pii:reveal权限并填写查看原因，每次查看都会记录审计日志
+// @Tags 志愿者管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "志愿者ID"
+// @Param request body models.RevealRequest true "查看原因"
+// @Success 200 {object} models.Response{data=models.Volunteer} "完整志愿者信息"
+// @Failure 400 {object} models.Response "无效的ID参数或未填写原因"
+// @Failure 403 {object} models.Response "缺少pii:reveal权限"
+// @Failure 404 {object} models.Response "未找到志愿者信息"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /volunteers/{id}/reveal [post]
+func (h *VolunteerHandler) RevealVolunteer(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	var req models.RevealRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	volunteer, err := h.service.GetVolunteer(c.Request.Context(), id)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	// 审计日志写入失败时不返回个人信息
+	if err := recordAudit(c, h.audit, models.AuditActionPIIReveal, "volunteer", id, req.Reason); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgPIIRevealed, volunteer)
 }
 
 // GetMyInfo godoc
@@ -144,28 +199,33 @@ c.JSON(200, gin.H{"message": "志愿者删除成功"})
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {object} models.Volunteer "志愿者个人信息"
+// @Param If-None-Match header string false "上次响应的ETag，信息未变化时返回304"
+// @Success 200 {object} models.Response{data=models.Volunteer} "志愿者个人信息，ETag响应头为当前版本号"
+// @Success 304 "信息未变化"
 // @Failure 401 {object} models.Response "未登录"
 // @Failure 403 {object} models.Response "无权限访问：非志愿者用户"
 // @Failure 404 {object} models.Response "未找到志愿者信息"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /volunteer/my-info [get]
 func (h *VolunteerHandler) GetMyInfo(c *gin.Context) {
-    // 从上下文获取当前用户ID
-    userID, exists := c.Get("userID")
-    if !exists {
-        c.JSON(401, gin.H{"error": "未登录"})
-        return
-    }
-
-    // 获取志愿者信息
-    volunteer, err := h.service.GetVolunteerInfo(userID.(uint))
-    if err != nil {
-        c.JSON(404, gin.H{"error": "未找到志愿者信息"})
-        return
-    }
-
-    c.JSON(200, volunteer)
+	// 从上下文获取当前用户ID
+	userID, err := currentUserID(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	// 获取志愿者信息
+	volunteer, err := h.service.GetVolunteerInfo(c.Request.Context(), userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if notModified(c, versionETag(volunteer.Version), volunteer.UpdatedAt) {
+		return
+	}
+	utils.RespondOK(c, http.StatusOK, i18n.MsgMyInfoOK, volunteer)
 }
 
 // UpdateMyInfo godoc
@@ -175,12 +235,14 @@ func (h *VolunteerHandler) GetMyInfo(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param If-Match header string false "志愿者信息当前版本号（即version字段或上次响应的ETag），不一致时返回412"
 // @Param info body models.UpdateVolunteerInfoRequest true "志愿者个人信息 (姓名、电话、邮箱、地址)"
 // @Success 200 {object} models.Response "更新成功"
 // @Failure 400 {object} models.Response "请求参数无效：1. 必填字段缺失 2. 邮箱格式错误"
 // @Failure 401 {object} models.Response "未登录"
 // @Failure 403 {object} models.Response "无权限访问：非志愿者用户"
 // @Failure 404 {object} models.Response "未找到志愿者信息"
+// @Failure 412 {object} models.Response "志愿者信息已被其他人修改"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Example {
 //   "request": {
@@ -192,29 +254,34 @@ func (h *VolunteerHandler) GetMyInfo(c *gin.Context) {
 // }
 // @Router /volunteer/my-info [put]
 func (h *VolunteerHandler) UpdateMyInfo(c *gin.Context) {
-    // 从上下文获取当前用户ID
-    userID, exists := c.Get("userID")
-    if !exists {
-        c.JSON(401, gin.H{"error": "未登录"})
-        return
-    }
-
-    // 验证请求体
-    var req models.UpdateVolunteerInfoRequest
-    if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(400, gin.H{"error": err.Error()})
-        return
-    }
-
-    // 更新志愿者信息
-    if err := h.service.UpdateVolunteerInfo(userID.(uint), &req); err != nil {
-        if err.Error() == "record not found" {
-            c.JSON(404, gin.H{"error": "未找到志愿者信息"})
-            return
-        }
-        c.JSON(500, gin.H{"error": "更新个人信息失败"})
-        return
-    }
-
-    c.JSON(200, gin.H{"message": "个人信息更新成功"})
+	// 从上下文获取当前用户ID
+	userID, err := currentUserID(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	// 验证请求体
+	var req models.UpdateVolunteerInfoRequest
+	if err := bindJSON(c, &req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	// 更新志愿者信息
+	volunteer, err := h.service.UpdateVolunteerInfo(c.Request.Context(), userID, &req, version)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	setETag(c, volunteer.Version)
+
+	utils.RespondOK(c, http.StatusOK, i18n.MsgMyInfoUpdated, nil)
 }
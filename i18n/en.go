@@ -0,0 +1,129 @@
+package i18n
+
+var enMessages = map[string]string{
+	// 成功消息
+	MsgUserRegistered:       "Registered successfully",
+	MsgLoginOK:              "Logged in successfully",
+	MsgPasswordChanged:      "Password changed successfully",
+	MsgLanguageUpdated:      "Language preference updated",
+	MsgUserListOK:           "User list retrieved",
+	MsgUserStatusUpdated:    "User status updated",
+	MsgUserDeleted:          "User deleted",
+	MsgActivityListOK:       "Activity list retrieved",
+	MsgAvailableActivityOK:  "Open activities retrieved",
+	MsgActivityDetailOK:     "Activity details retrieved",
+	MsgActivityCreated:      "Activity created",
+	MsgActivityUpdated:      "Activity updated",
+	MsgActivityDeleted:      "Activity deleted",
+	MsgCategoryListOK:       "Activity categories retrieved",
+	MsgCategoryCreated:      "Activity category created",
+	MsgCategoryUpdated:      "Activity category updated",
+	MsgCategoryDeleted:      "Activity category deleted",
+	MsgCategoryStatsOK:      "Category statistics retrieved",
+	MsgTagListOK:            "Tag list retrieved",
+	MsgSeriesListOK:         "Activity series retrieved",
+	MsgSeriesOK:             "Activity series details retrieved",
+	MsgSeriesCreated:        "Activity series created",
+	MsgSeriesUpdated:        "Activity series updated",
+	MsgSeriesCancelled:      "Activity series cancelled",
+	MsgVolunteerListOK:      "Volunteer list retrieved",
+	MsgVolunteerCreated:     "Volunteer created",
+	MsgVolunteerUpdated:     "Volunteer updated",
+	MsgVolunteerDeleted:     "Volunteer deleted",
+	MsgMyInfoOK:             "Profile retrieved",
+	MsgMyInfoUpdated:        "Profile updated",
+	MsgRegistrationListOK:   "Registration list retrieved",
+	MsgRegistrationStatusOK: "Registration status updated",
+	MsgRegistrationCreated:  "Signed up successfully",
+	MsgRegistrationOK:       "Registration retrieved",
+	MsgPermissionsUpdated:   "User permissions updated",
+	MsgPIIRevealed:          "Access audited, full personal data returned",
+	MsgAuditLogListOK:       "Audit logs retrieved",
+	MsgDataExported:         "Personal data exported",
+	MsgUserAnonymized:       "Personal data anonymized",
+	MsgRetentionRulesOK:     "Retention rules retrieved",
+	MsgRetentionReportOK:    "Retention dry run completed",
+	MsgRetentionRunOK:       "Retention policies applied",
+	MsgTrashListOK:          "Trash retrieved",
+	MsgTrashRestored:        "Restored successfully",
+	MsgTrashPurged:          "Permanently deleted",
+	MsgHealthy:              "Service is alive",
+	MsgReady:                "Service is ready",
+	MsgBuildInfoOK:          "Build information retrieved",
+
+	// 校验提示
+	MsgFieldType: "%s must be of type %s",
+
+	// 通用错误
+	"INTERNAL_ERROR":  "Internal server error",
+	"INVALID_REQUEST": "Invalid request data",
+	"INVALID_ID":      "Invalid ID parameter",
+	"REQUEST_TIMEOUT": "Request timed out, please try again later",
+	"BODY_TOO_LARGE":  "Request body exceeds the size limit",
+	"NOT_READY":       "Service is not ready",
+	"RATE_LIMITED":    "Too many requests, please try again later",
+
+	// 并发控制错误
+	"INVALID_IF_MATCH": "Invalid If-Match header",
+	"VERSION_CONFLICT": "The resource was modified by someone else; reload and try again",
+
+	// 幂等请求错误
+	"INVALID_IDEMPOTENCY_KEY": "Invalid Idempotency-Key header",
+	"IDEMPOTENCY_KEY_REUSED":  "This Idempotency-Key was already used for a different request; use a new key",
+	"IDEMPOTENCY_IN_PROGRESS": "A request with the same Idempotency-Key is still being processed; try again later",
+
+	// 认证与权限错误
+	"TOKEN_MISSING":      "Authentication token is missing",
+	"TOKEN_MALFORMED":    "Malformed authentication token",
+	"TOKEN_INVALID":      "Invalid authentication token",
+	"USER_INVALID":       "Invalid user",
+	"ROLE_MISMATCH":      "User role verification failed",
+	"NOT_LOGGED_IN":      "Not logged in",
+	"USER_DISABLED":      "User has been disabled",
+	"ADMIN_REQUIRED":     "Administrator permission required",
+	"VOLUNTEER_REQUIRED": "Volunteer permission required",
+	"PERMISSION_DENIED":  "Missing required permission",
+
+	// 用户错误
+	"USER_NOT_FOUND":       "User not found",
+	"USERNAME_TAKEN":       "Username already exists",
+	"INVALID_ROLE":         "Invalid user role",
+	"INVALID_CREDENTIALS":  "Incorrect username or password",
+	"ACCOUNT_DISABLED":     "This account has been disabled",
+	"WRONG_PASSWORD":       "Old password is incorrect",
+	"PASSWORD_INCORRECT":   "Incorrect password",
+	"UNSUPPORTED_LANGUAGE": "Unsupported language",
+
+	// 活动错误
+	"ACTIVITY_NOT_FOUND":         "Activity not found",
+	"ACTIVITY_DATE_REQUIRED":     "Activity date is required",
+	"ACTIVITY_NOT_OPEN":          "Activity is not open for registration",
+	"ACTIVITY_FULL":              "Activity is full",
+	"ACTIVITY_HAS_REGISTRATIONS": "The activity already has registrations and cannot be reverted to a draft",
+	"AGE_BELOW_MINIMUM":          "You do not meet the minimum age for this activity",
+	"AGE_ABOVE_MAXIMUM":          "You exceed the maximum age for this activity",
+	"BIRTH_DATE_UNKNOWN":         "Unable to determine the participant's date of birth",
+
+	// 分类错误
+	"CATEGORY_NOT_FOUND":  "Activity category not found",
+	"CATEGORY_NAME_TAKEN": "An activity category with this name already exists",
+	"UNKNOWN_CATEGORY":    "The activity references a category that does not exist",
+
+	// 活动系列错误
+	"SERIES_NOT_FOUND":   "Activity series not found",
+	"SERIES_CANCELLED":   "The activity series has been cancelled",
+	"RECURRENCE_INVALID": "The recurrence rule does not produce any occurrences",
+
+	// 志愿者错误
+	"VOLUNTEER_NOT_FOUND": "Volunteer profile not found",
+
+	// 报名错误
+	"REGISTRATION_NOT_FOUND":      "Registration not found",
+	"ALREADY_REGISTERED":          "You have already signed up for this activity",
+	"DOCUMENT_ALREADY_REGISTERED": "This document has already been used to sign up for this activity",
+
+	// 回收站错误
+	"TRASH_TYPE_INVALID":   "Invalid trash type",
+	"TRASH_ITEM_NOT_FOUND": "Item not found in trash",
+	"TRASH_OWNER_DELETED":  "The owning user is still in the trash; restore the user first",
+}
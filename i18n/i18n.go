@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// 支持的语言
+const (
+	LangZH = "zh"
+	LangEN = "en"
+)
+
+// DefaultLang 默认语言
+const DefaultLang = LangZH
+
+// ContextKey gin上下文中保存当前请求语言的键
+const ContextKey = "lang"
+
+var catalogs = map[string]map[string]string{
+	LangZH: zhMessages,
+	LangEN: enMessages,
+}
+
+var matcher = language.NewMatcher([]language.Tag{
+	language.Chinese,
+	language.English,
+})
+
+// IsSupported 判断是否为支持的语言
+func IsSupported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Negotiate 根据Accept-Language请求头选择语言
+func Negotiate(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return DefaultLang
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLang
+	}
+	tag, _, _ := matcher.Match(tags...)
+	base, _ := tag.Base()
+	if IsSupported(base.String()) {
+		return base.String()
+	}
+	return DefaultLang
+}
+
+// FromContext 获取当前请求的语言，未经中间件设置时按请求头协商
+func FromContext(c *gin.Context) string {
+	if lang := c.GetString(ContextKey); lang != "" {
+		return lang
+	}
+	return Negotiate(c.GetHeader("Accept-Language"))
+}
+
+// T 翻译消息ID，找不到时回退到默认语言，仍找不到则返回ID本身
+func T(lang, id string, args ...interface{}) string {
+	msg, ok := catalogs[lang][id]
+	if !ok {
+		msg, ok = catalogs[DefaultLang][id]
+	}
+	if !ok {
+		return id
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Has 判断消息ID是否存在于默认语言目录中
+func Has(id string) bool {
+	_, ok := catalogs[DefaultLang][id]
+	return ok
+}
@@ -0,0 +1,58 @@
+package i18n
+
+// 成功响应的消息ID，错误响应使用errs包中定义的错误码作为消息ID
+const (
+	MsgUserRegistered       = "USER_REGISTERED"
+	MsgLoginOK              = "LOGIN_OK"
+	MsgPasswordChanged      = "PASSWORD_CHANGED"
+	MsgLanguageUpdated      = "LANGUAGE_UPDATED"
+	MsgUserListOK           = "USER_LIST_OK"
+	MsgUserStatusUpdated    = "USER_STATUS_UPDATED"
+	MsgUserDeleted          = "USER_DELETED"
+	MsgActivityListOK       = "ACTIVITY_LIST_OK"
+	MsgAvailableActivityOK  = "AVAILABLE_ACTIVITY_LIST_OK"
+	MsgActivityDetailOK     = "ACTIVITY_DETAIL_OK"
+	MsgActivityCreated      = "ACTIVITY_CREATED"
+	MsgActivityUpdated      = "ACTIVITY_UPDATED"
+	MsgActivityDeleted      = "ACTIVITY_DELETED"
+	MsgCategoryListOK       = "CATEGORY_LIST_OK"
+	MsgCategoryCreated      = "CATEGORY_CREATED"
+	MsgCategoryUpdated      = "CATEGORY_UPDATED"
+	MsgCategoryDeleted      = "CATEGORY_DELETED"
+	MsgCategoryStatsOK      = "CATEGORY_STATS_OK"
+	MsgTagListOK            = "TAG_LIST_OK"
+	MsgSeriesListOK         = "SERIES_LIST_OK"
+	MsgSeriesOK             = "SERIES_OK"
+	MsgSeriesCreated        = "SERIES_CREATED"
+	MsgSeriesUpdated        = "SERIES_UPDATED"
+	MsgSeriesCancelled      = "SERIES_CANCEL_OK"
+	MsgVolunteerListOK      = "VOLUNTEER_LIST_OK"
+	MsgVolunteerCreated     = "VOLUNTEER_CREATED"
+	MsgVolunteerUpdated     = "VOLUNTEER_UPDATED"
+	MsgVolunteerDeleted     = "VOLUNTEER_DELETED"
+	MsgMyInfoOK             = "MY_INFO_OK"
+	MsgMyInfoUpdated        = "MY_INFO_UPDATED"
+	MsgRegistrationListOK   = "REGISTRATION_LIST_OK"
+	MsgRegistrationStatusOK = "REGISTRATION_STATUS_UPDATED"
+	MsgRegistrationCreated  = "REGISTRATION_CREATED"
+	MsgRegistrationOK       = "REGISTRATION_OK"
+	MsgPermissionsUpdated   = "PERMISSIONS_UPDATED"
+	MsgPIIRevealed          = "PII_REVEALED"
+	MsgAuditLogListOK       = "AUDIT_LOG_LIST_OK"
+	MsgDataExported         = "DATA_EXPORTED"
+	MsgUserAnonymized       = "USER_ANONYMIZED"
+	MsgRetentionRulesOK     = "RETENTION_RULES_OK"
+	MsgRetentionReportOK    = "RETENTION_REPORT_OK"
+	MsgRetentionRunOK       = "RETENTION_RUN_OK"
+	MsgTrashListOK          = "TRASH_LIST_OK"
+	MsgTrashRestored        = "TRASH_RESTORED"
+	MsgTrashPurged          = "TRASH_PURGED"
+	MsgHealthy              = "HEALTHY"
+	MsgReady                = "READY"
+	MsgBuildInfoOK          = "BUILD_INFO_OK"
+)
+
+// 校验提示的消息ID
+const (
+	MsgFieldType = "FIELD_TYPE"
+)
@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	zh_translations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+var universal = ut.New(zh.New(), zh.New(), en.New())
+
+// customTagMessages 自定义校验标签的错误信息，{0}为字段名
+var customTagMessages = map[string]map[string]string{
+	"cn_mobile": {
+		LangZH: "{0}必须是有效的手机号码",
+		LangEN: "{0} must be a valid mobile phone number",
+	},
+	"cn_idcard": {
+		LangZH: "{0}必须是有效的身份证号码",
+		LangEN: "{0} must be a valid resident ID card number",
+	},
+	"document_number": {
+		LangZH: "{0}不是有效的证件号码",
+		LangEN: "{0} is not a valid document number",
+	},
+	"activity_date_range": {
+		LangZH: "{0}不能早于活动开始时间",
+		LangEN: "{0} must not be earlier than the activity start date",
+	},
+}
+
+// RegisterValidatorTranslations 为校验器注册各语言的错误信息翻译
+func RegisterValidatorTranslations(v *validator.Validate) error {
+	zhTrans, _ := universal.GetTranslator(LangZH)
+	if err := zh_translations.RegisterDefaultTranslations(v, zhTrans); err != nil {
+		return err
+	}
+	enTrans, _ := universal.GetTranslator(LangEN)
+	if err := en_translations.RegisterDefaultTranslations(v, enTrans); err != nil {
+		return err
+	}
+
+	for tag, messages := range customTagMessages {
+		for lang, text := range messages {
+			if err := registerTagTranslation(v, lang, tag, text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// registerTagTranslation 注册单个校验标签在指定语言下的翻译
+func registerTagTranslation(v *validator.Validate, lang, tag, text string) error {
+	trans := Translator(lang)
+	return v.RegisterTranslation(tag, trans,
+		func(ut ut.Translator) error {
+			return ut.Add(tag, text, true)
+		},
+		func(ut ut.Translator, fe validator.FieldError) string {
+			msg, err := ut.T(tag, fe.Field())
+			if err != nil {
+				return fe.Error()
+			}
+			return msg
+		},
+	)
+}
+
+// Translator 获取指定语言的校验错误翻译器
+func Translator(lang string) ut.Translator {
+	trans, found := universal.GetTranslator(lang)
+	if !found {
+		trans, _ = universal.GetTranslator(DefaultLang)
+	}
+	return trans
+}
@@ -0,0 +1,129 @@
+package i18n
+
+var zhMessages = map[string]string{
+	// 成功消息
+	MsgUserRegistered:       "注册成功",
+	MsgLoginOK:              "登录成功",
+	MsgPasswordChanged:      "密码修改成功",
+	MsgLanguageUpdated:      "语言偏好更新成功",
+	MsgUserListOK:           "获取用户列表成功",
+	MsgUserStatusUpdated:    "用户状态更新成功",
+	MsgUserDeleted:          "用户删除成功",
+	MsgActivityListOK:       "获取活动列表成功",
+	MsgAvailableActivityOK:  "获取可报名活动列表成功",
+	MsgActivityDetailOK:     "获取活动详情成功",
+	MsgActivityCreated:      "创建活动成功",
+	MsgActivityUpdated:      "更新活动成功",
+	MsgActivityDeleted:      "活动删除成功",
+	MsgCategoryListOK:       "获取活动分类成功",
+	MsgCategoryCreated:      "创建活动分类成功",
+	MsgCategoryUpdated:      "更新活动分类成功",
+	MsgCategoryDeleted:      "活动分类删除成功",
+	MsgCategoryStatsOK:      "获取分类统计成功",
+	MsgTagListOK:            "获取标签列表成功",
+	MsgSeriesListOK:         "获取活动系列成功",
+	MsgSeriesOK:             "获取活动系列详情成功",
+	MsgSeriesCreated:        "创建活动系列成功",
+	MsgSeriesUpdated:        "更新活动系列成功",
+	MsgSeriesCancelled:      "活动系列已取消",
+	MsgVolunteerListOK:      "获取志愿者列表成功",
+	MsgVolunteerCreated:     "创建志愿者成功",
+	MsgVolunteerUpdated:     "更新志愿者成功",
+	MsgVolunteerDeleted:     "志愿者删除成功",
+	MsgMyInfoOK:             "获取个人信息成功",
+	MsgMyInfoUpdated:        "个人信息更新成功",
+	MsgRegistrationListOK:   "获取报名列表成功",
+	MsgRegistrationStatusOK: "报名状态更新成功",
+	MsgRegistrationCreated:  "报名成功",
+	MsgRegistrationOK:       "获取报名记录成功",
+	MsgPermissionsUpdated:   "用户权限更新成功",
+	MsgPIIRevealed:          "已记录审计日志，返回完整个人信息",
+	MsgAuditLogListOK:       "获取审计日志成功",
+	MsgDataExported:         "个人数据导出成功",
+	MsgUserAnonymized:       "个人数据已匿名化",
+	MsgRetentionRulesOK:     "获取数据保留规则成功",
+	MsgRetentionReportOK:    "数据保留试运行完成",
+	MsgRetentionRunOK:       "数据保留策略执行完成",
+	MsgTrashListOK:          "获取回收站成功",
+	MsgTrashRestored:        "恢复成功",
+	MsgTrashPurged:          "彻底删除成功",
+	MsgHealthy:              "服务运行正常",
+	MsgReady:                "服务已就绪",
+	MsgBuildInfoOK:          "获取版本信息成功",
+
+	// 校验提示
+	MsgFieldType: "%s的类型应为%s",
+
+	// 通用错误
+	"INTERNAL_ERROR":  "服务器内部错误",
+	"INVALID_REQUEST": "请求数据无效",
+	"INVALID_ID":      "无效的ID参数",
+	"REQUEST_TIMEOUT": "请求处理超时，请稍后重试",
+	"BODY_TOO_LARGE":  "请求体超过大小限制",
+	"NOT_READY":       "服务尚未就绪",
+	"RATE_LIMITED":    "请求过于频繁，请稍后重试",
+
+	// 并发控制错误
+	"INVALID_IF_MATCH": "无效的If-Match请求头",
+	"VERSION_CONFLICT": "数据已被其他人修改，请刷新后重试",
+
+	// 幂等请求错误
+	"INVALID_IDEMPOTENCY_KEY": "无效的Idempotency-Key请求头",
+	"IDEMPOTENCY_KEY_REUSED":  "该Idempotency-Key已用于内容不同的请求，请使用新的Key",
+	"IDEMPOTENCY_IN_PROGRESS": "使用相同Idempotency-Key的请求正在处理中，请稍后重试",
+
+	// 认证与权限错误
+	"TOKEN_MISSING":      "未提供认证token",
+	"TOKEN_MALFORMED":    "无效的token格式",
+	"TOKEN_INVALID":      "无效的token",
+	"USER_INVALID":       "无效的用户",
+	"ROLE_MISMATCH":      "用户角色验证失败",
+	"NOT_LOGGED_IN":      "未登录",
+	"USER_DISABLED":      "用户已被禁用",
+	"ADMIN_REQUIRED":     "需要管理员权限",
+	"VOLUNTEER_REQUIRED": "需要志愿者权限",
+	"PERMISSION_DENIED":  "缺少所需的附加权限",
+
+	// 用户错误
+	"USER_NOT_FOUND":       "用户不存在",
+	"USERNAME_TAKEN":       "用户名已存在",
+	"INVALID_ROLE":         "无效的用户角色",
+	"INVALID_CREDENTIALS":  "用户名或密码错误",
+	"ACCOUNT_DISABLED":     "用户账号已被禁用",
+	"WRONG_PASSWORD":       "旧密码错误",
+	"PASSWORD_INCORRECT":   "密码错误",
+	"UNSUPPORTED_LANGUAGE": "不支持的语言",
+
+	// 活动错误
+	"ACTIVITY_NOT_FOUND":         "活动不存在",
+	"ACTIVITY_DATE_REQUIRED":     "活动日期不能为空",
+	"ACTIVITY_NOT_OPEN":          "活动不在报名阶段",
+	"ACTIVITY_FULL":              "活动名额已满",
+	"ACTIVITY_HAS_REGISTRATIONS": "活动已有报名，不能改回草稿",
+	"AGE_BELOW_MINIMUM":          "未达到活动的最低年龄要求",
+	"AGE_ABOVE_MAXIMUM":          "超过活动的最高年龄限制",
+	"BIRTH_DATE_UNKNOWN":         "无法确定报名人出生日期",
+
+	// 分类错误
+	"CATEGORY_NOT_FOUND":  "活动分类不存在",
+	"CATEGORY_NAME_TAKEN": "活动分类名称已存在",
+	"UNKNOWN_CATEGORY":    "活动包含不存在的分类",
+
+	// 活动系列错误
+	"SERIES_NOT_FOUND":   "活动系列不存在",
+	"SERIES_CANCELLED":   "活动系列已取消",
+	"RECURRENCE_INVALID": "重复规则无法产生任何场次",
+
+	// 志愿者错误
+	"VOLUNTEER_NOT_FOUND": "未找到志愿者信息",
+
+	// 报名错误
+	"REGISTRATION_NOT_FOUND":      "未找到报名记录",
+	"ALREADY_REGISTERED":          "已经报名过该活动",
+	"DOCUMENT_ALREADY_REGISTERED": "该证件已报名过该活动",
+
+	// 回收站错误
+	"TRASH_TYPE_INVALID":   "无效的回收站类型",
+	"TRASH_ITEM_NOT_FOUND": "回收站中不存在该记录",
+	"TRASH_OWNER_DELETED":  "所属用户仍在回收站中，请先恢复用户",
+}
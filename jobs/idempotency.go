@@ -0,0 +1,20 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/repository"
+	"time"
+)
+
+// NewIdempotencyCleanupJob 创建定期删除过期幂等请求记录的后台任务
+func NewIdempotencyCleanupJob(repo repository.IdempotencyRepository, interval time.Duration, logger *slog.Logger) *Periodic {
+	return NewPeriodic("idempotency-cleanup", interval, func(ctx context.Context) error {
+		deleted, err := repo.DeleteExpired(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		logger.DebugContext(ctx, "已清理过期幂等请求记录", "deleted", deleted)
+		return nil
+	}, logger)
+}
@@ -0,0 +1,124 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/metrics"
+	"seaguard-admin-backend/models"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Periodic 按固定间隔执行的后台任务，启动后立即执行一次
+type Periodic struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+	logger   *slog.Logger
+
+	mu        sync.RWMutex
+	startedAt time.Time
+	lastRun   time.Time
+	lastErr   error
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewPeriodic 创建后台任务实例
+func NewPeriodic(name string, interval time.Duration, run func(ctx context.Context) error, logger *slog.Logger) *Periodic {
+	return &Periodic{
+		name:     name,
+		interval: interval,
+		run:      run,
+		logger:   logger.With("job", name),
+	}
+}
+
+// Start 在后台协程中启动任务，重复调用无效
+func (p *Periodic) Start(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		return
+	}
+
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.done = make(chan struct{})
+	p.startedAt = time.Now()
+	go p.loop(ctx, p.done)
+}
+
+// Stop 停止任务并等待正在执行的一轮结束
+func (p *Periodic) Stop() {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.cancel, p.done = nil, nil
+	p.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Status 返回任务最近一次执行的状态；运行中的任务超过两个执行间隔没有完成一轮执行时视为停滞
+func (p *Periodic) Status() models.WorkerStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	status := models.WorkerStatus{
+		Name:    p.name,
+		Running: p.cancel != nil,
+		LastRun: p.lastRun,
+		Failed:  p.lastErr != nil,
+	}
+	if status.Running {
+		since := p.lastRun
+		if since.IsZero() {
+			since = p.startedAt
+		}
+		status.Stale = time.Since(since) > 2*p.interval
+	}
+	return status
+}
+
+func (p *Periodic) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.execute(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Periodic) execute(ctx context.Context) {
+	// 每轮执行作为独立的根span，其中的数据库语句会挂在该span下
+	ctx, span := otel.Tracer("seaguard-admin-backend/jobs").Start(ctx, "job."+p.name,
+		trace.WithNewRoot(), trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	err := p.run(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.logger.ErrorContext(ctx, "后台任务执行失败", "error", err)
+	}
+	metrics.RecordJobRun(p.name, err)
+
+	p.mu.Lock()
+	p.lastRun = time.Now()
+	p.lastErr = err
+	p.mu.Unlock()
+}
@@ -0,0 +1,20 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/repository"
+	"time"
+)
+
+// NewRateLimitCleanupJob 创建定期清理数据库中已补满令牌桶的后台任务
+func NewRateLimitCleanupJob(repo repository.RateLimitRepository, interval time.Duration, logger *slog.Logger) *Periodic {
+	return NewPeriodic("ratelimit-cleanup", interval, func(ctx context.Context) error {
+		deleted, err := repo.DeleteFull(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		logger.DebugContext(ctx, "已清理限流令牌桶", "deleted", deleted)
+		return nil
+	}, logger)
+}
@@ -0,0 +1,16 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/service"
+	"time"
+)
+
+// NewRetentionJob 创建定期执行数据保留策略的后台任务，各规则的执行结果由服务记录日志
+func NewRetentionJob(retention service.RetentionService, interval time.Duration, dryRun bool, logger *slog.Logger) *Periodic {
+	return NewPeriodic("retention", interval, func(ctx context.Context) error {
+		_, err := retention.Run(ctx, dryRun)
+		return err
+	}, logger)
+}
@@ -0,0 +1,16 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/service"
+	"time"
+)
+
+// NewSeriesGenerationJob 创建定期补齐活动系列场次的后台任务，使生成的场次始终覆盖滚动窗口
+func NewSeriesGenerationJob(series service.SeriesService, interval time.Duration, logger *slog.Logger) *Periodic {
+	return NewPeriodic("series-generation", interval, func(ctx context.Context) error {
+		_, err := series.GenerateOccurrences(ctx)
+		return err
+	}, logger)
+}
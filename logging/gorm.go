@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger 将GORM日志写入slog：语句错误记为error，慢查询记为warn，其余语句在debug级别输出
+type GormLogger struct {
+	logger        *slog.Logger
+	slowThreshold time.Duration
+}
+
+// NewGormLogger 创建GORM日志适配器
+func NewGormLogger(logger *slog.Logger, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{logger: logger.With("component", "gorm"), slowThreshold: slowThreshold}
+}
+
+// WithLogger 返回写入logger、慢查询阈值不变的副本
+func (l *GormLogger) WithLogger(logger *slog.Logger) *GormLogger {
+	return NewGormLogger(logger, l.slowThreshold)
+}
+
+// LogMode 实现gormlogger.Interface，级别由slog统一控制
+func (l *GormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return l
+}
+
+// Info 实现gormlogger.Interface
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.InfoContext(ctx, msg, "args", args)
+}
+
+// Warn 实现gormlogger.Interface
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.WarnContext(ctx, msg, "args", args)
+}
+
+// Error 实现gormlogger.Interface
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.ErrorContext(ctx, msg, "args", args)
+}
+
+// Trace 实现gormlogger.Interface，记录语句执行结果
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, context.Canceled):
+		sql, rows := fc()
+		l.logger.ErrorContext(ctx, "数据库语句执行失败", "sql", sql, "rows", rows, "elapsed", elapsed, "error", err)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold:
+		sql, rows := fc()
+		l.logger.WarnContext(ctx, "慢查询", "sql", sql, "rows", rows, "elapsed", elapsed)
+	case l.logger.Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		l.logger.DebugContext(ctx, "数据库语句", "sql", sql, "rows", rows, "elapsed", elapsed)
+	}
+}
@@ -1,20 +1,35 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"os"
+	"time"
 
 	"seaguard-admin-backend/config"
 	"seaguard-admin-backend/handlers"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/jobs"
+	"seaguard-admin-backend/logging"
+	"seaguard-admin-backend/metrics"
 	"seaguard-admin-backend/middleware"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/ratelimit"
 	"seaguard-admin-backend/repository"
+	"seaguard-admin-backend/server"
 	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/tracing"
+	"seaguard-admin-backend/utils/fieldcrypt"
 
 	_ "seaguard-admin-backend/docs"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // @title           SeaGuard Admin API
@@ -26,89 +41,276 @@ import (
 // @BasePath  /api
 func main() {
 	// @Summary 主函数，初始化和启动服务器
+	// 加载配置
+	config.LoadConfig()
+
+	// 初始化结构化日志，标准库log的输出也写入同一日志
+	logger, err := logging.New(os.Stdout, config.App.LogLevel, config.App.LogFormat)
+	if err != nil {
+		log.Fatal("Invalid logging config:", err)
+	}
+	slog.SetDefault(logger)
+
+	// 初始化链路追踪
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    config.App.TraceExporter,
+		File:        config.App.TraceFile,
+		SampleRatio: config.App.TraceSampleRatio,
+	})
+	if err != nil {
+		log.Fatal("Failed to init tracing:", err)
+	}
+
+	// 初始化字段加密密钥环
+	keyring, err := fieldcrypt.NewKeyring(config.App.EncryptionKeys, config.App.EncryptionActiveKey, config.App.BlindIndexKey, config.App.DevMode)
+	if err != nil {
+		log.Fatal("Failed to init encryption keyring:", err)
+	}
+	fieldcrypt.Setup(keyring)
+
 	// 初始化数据库
-	config.InitDatabase()
+	config.InitDatabase(logger)
+
+	// 注册自定义校验规则及校验错误信息的多语言翻译
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := models.RegisterValidators(v); err != nil {
+			log.Fatal("Failed to register validators:", err)
+		}
+		if err := i18n.RegisterValidatorTranslations(v); err != nil {
+			log.Fatal("Failed to register validator translations:", err)
+		}
+	}
 
 	// 初始化repository层
-	userRepo := repository.NewUserRepository(config.DB)
-	activityRepo := repository.NewActivityRepository()
-	volunteerRepo := repository.NewVolunteerRepository()
-	registrationRepo := repository.NewRegistrationRepository()
+	userRepo := repository.NewUserRepository(config.DB, logger)
+	activityRepo := repository.NewActivityRepository(config.DB, logger)
+	categoryRepo := repository.NewCategoryRepository(config.DB, logger)
+	seriesRepo := repository.NewSeriesRepository(config.DB, logger)
+	volunteerRepo := repository.NewVolunteerRepository(config.DB, logger)
+	registrationRepo := repository.NewRegistrationRepository(config.DB, logger)
+	auditRepo := repository.NewAuditRepository(config.DB, logger)
+	retentionRepo := repository.NewRetentionRepository(config.DB, logger)
+	healthRepo := repository.NewHealthRepository(config.DB, logger)
+	statsRepo := repository.NewStatsRepository(config.DB, logger)
+	rateLimitRepo := repository.NewRateLimitRepository(config.DB, logger)
+	idempotencyRepo := repository.NewIdempotencyRepository(config.DB, logger)
+	uow := repository.NewUnitOfWork(config.DB, logger)
 
 	// 初始化service层
-	userService := service.NewUserService(userRepo)
-	activityService := service.NewActivityService(activityRepo)
-	volunteerService := service.NewVolunteerService(volunteerRepo)
-	registrationService := service.NewRegistrationService(registrationRepo, activityRepo)
+	activityCache := service.NewActivityCache(config.App.ActivityCacheTTL)
+	userService := service.NewUserService(userRepo, uow, logger)
+	activityService := service.NewActivityService(activityRepo, registrationRepo, uow, activityCache, logger)
+	categoryService := service.NewCategoryService(categoryRepo, activityCache, logger)
+	seriesService := service.NewSeriesService(seriesRepo, activityRepo, uow, activityCache, config.App.SeriesHorizon, logger)
+	volunteerService := service.NewVolunteerService(volunteerRepo, logger)
+	registrationService := service.NewRegistrationService(registrationRepo, activityRepo, uow, activityCache, logger)
+	auditService := service.NewAuditService(auditRepo)
+	privacyService := service.NewPrivacyService(userRepo, volunteerRepo, registrationRepo, activityRepo, uow, logger)
+	retentionService := service.NewRetentionService(retentionRepo, auditService, config.App.RetentionRules, logger)
+	trashService := service.NewTrashService(userRepo, volunteerRepo, activityRepo, uow, activityCache, logger)
+
+	// 创建后台任务
+	var workers []*jobs.Periodic
+	if config.App.RetentionEnabled {
+		workers = append(workers, jobs.NewRetentionJob(retentionService, config.App.RetentionInterval, config.App.RetentionDryRun, logger))
+	}
+	if config.App.RateLimitEnabled && config.App.RateLimitStore == "db" {
+		workers = append(workers, jobs.NewRateLimitCleanupJob(rateLimitRepo, config.App.RateLimitGCInterval, logger))
+	}
+	workers = append(workers, jobs.NewSeriesGenerationJob(seriesService, config.App.SeriesInterval, logger))
+	// 过期的幂等请求记录每小时清理一次
+	workers = append(workers, jobs.NewIdempotencyCleanupJob(idempotencyRepo, time.Hour, logger))
+	healthWorkers := make([]service.Worker, 0, len(workers))
+	for _, worker := range workers {
+		healthWorkers = append(healthWorkers, worker)
+	}
+	healthService := service.NewHealthService(healthRepo, healthWorkers, logger)
 
 	// 初始化handlers
 	userHandler := handlers.NewUserHandler(userService)
 	activityHandler := handlers.NewActivityHandler(activityService)
-	volunteerHandler := handlers.NewVolunteerHandler(volunteerService)
-	registrationHandler := handlers.NewRegistrationHandler(registrationService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	seriesHandler := handlers.NewSeriesHandler(seriesService)
+	volunteerHandler := handlers.NewVolunteerHandler(volunteerService, auditService)
+	registrationHandler := handlers.NewRegistrationHandler(registrationService, auditService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	privacyHandler := handlers.NewPrivacyHandler(privacyService, auditService)
+	retentionHandler := handlers.NewRetentionHandler(retentionService)
+	trashHandler := handlers.NewTrashHandler(trashService, auditService)
+	healthHandler := handlers.NewHealthHandler(healthService)
+
+	// 初始化限流器
+	var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if config.App.RateLimitStore == "db" {
+		rateLimitStore = rateLimitRepo
+	}
+	limiter := ratelimit.NewLimiter(rateLimitStore, config.App.RateLimitPolicies, config.App.RateLimitAPIKeys)
+
+	// 启动后台任务
+	for _, worker := range workers {
+		worker.Start(context.Background())
+	}
+
+	// 创建gin引擎，探针请求频繁，不写入访问日志
+	r := gin.New()
+	// 只信任配置的反向代理传来的X-Forwarded-For，避免客户端伪造IP绕过按IP限流
+	if err := r.SetTrustedProxies(config.App.TrustedProxies); err != nil {
+		log.Fatal("Invalid SEAGUARD_TRUSTED_PROXIES:", err)
+	}
+	r.Use(middleware.AccessLog(logger, "/healthz", "/readyz", "/metrics"))
+	r.Use(otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(tracing.SkipProbes)))
+	r.Use(middleware.RequestID(), gin.Recovery())
+	if config.App.MetricsEnabled {
+		r.Use(metrics.Middleware())
+	}
+
+	// 安全响应头可按环境关闭，跨域只允许配置的来源
+	if config.App.SecurityHeaders {
+		r.Use(middleware.SecurityHeaders(config.App.HSTSMaxAge, config.App.HSTSSubdomains, "/swagger/"))
+	}
+	corsMiddleware, err := middleware.CORS(config.App.CORSOrigins, config.App.CORSCredentials, config.App.CORSMaxAge)
+	if err != nil {
+		log.Fatal("Invalid CORS config:", err)
+	}
+	r.Use(corsMiddleware)
+	r.Use(middleware.Locale())
+	r.Use(middleware.BodyLimit(config.App.MaxBodyBytes))
+	r.Use(middleware.Timeout(config.App.RequestTimeout, config.App.RouteTimeouts))
 
-	// 创建gin引擎
-	r := gin.Default()
+	// 健康检查与版本信息（无需认证，供容器编排系统探测）
+	r.GET("/healthz", healthHandler.Healthz)
+	r.GET("/readyz", healthHandler.Readyz)
+	r.GET("/version", healthHandler.Version)
 
-	// CORS配置
-	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-	r.Use(cors.New(config))
+	// Prometheus指标
+	if config.App.MetricsEnabled {
+		metrics.RegisterDomain(statsRepo, logger)
+		r.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
 
-	// 认证相关路由（无需认证）
-	r.POST("/api/auth/register", userHandler.Register)
-	r.POST("/api/auth/login", userHandler.Login)
+	// /api下的请求在认证前按IP限流；按API Key限流在认证后进行，未提供API Key时退回按用户限流
+	api := r.Group("/api")
+	if config.App.RateLimitEnabled {
+		api.Use(middleware.RateLimit(limiter, logger, ratelimit.KeyIP))
+	}
+
+	// 创建类接口支持Idempotency-Key，超过请求处理超时仍未完成的首次请求视为已中断
+	idempotent := middleware.Idempotency(idempotencyRepo, config.App.IdempotencyTTL, config.App.RequestTimeout, logger)
+
+	// 认证相关路由（无需认证），未提供API Key时按IP计入API Key策略
+	public := api.Group("")
+	if config.App.RateLimitEnabled {
+		public.Use(middleware.RateLimit(limiter, logger, ratelimit.KeyAPIKey))
+	}
+	public.POST("/auth/register", idempotent, userHandler.Register)
+	public.POST("/auth/login", userHandler.Login)
 
 	// 用户相关路由（需要认证）
-	auth := r.Group("/api", middleware.AuthMiddleware(userService))
+	auth := api.Group("", middleware.AuthMiddleware(userService, logger))
+	if config.App.RateLimitEnabled {
+		auth.Use(middleware.RateLimit(limiter, logger, ratelimit.KeyUser, ratelimit.KeyAPIKey))
+	}
 	{
 		// 用户管理（仅管理员）
 		admin := auth.Group("", middleware.AdminRequired())
 		{
 			admin.GET("/users", userHandler.ListUsers)
 			admin.PUT("/users/:id/status", userHandler.UpdateUserStatus)
+			admin.PUT("/users/:id/permissions", userHandler.UpdateUserPermissions)
+			admin.POST("/users/:id/anonymize", privacyHandler.AnonymizeUser)
 			admin.DELETE("/users/:id", userHandler.DeleteUser)
+			admin.GET("/admin/audit-logs", auditHandler.ListAuditLogs)
+			admin.GET("/admin/retention/rules", retentionHandler.ListRetentionRules)
+			admin.GET("/admin/retention/report", retentionHandler.PreviewRetention)
+			admin.POST("/admin/retention/run", retentionHandler.RunRetention)
+
+			// 回收站
+			admin.GET("/admin/trash", trashHandler.ListTrash)
+			admin.POST("/admin/trash/:type/:id/restore", trashHandler.RestoreTrashItem)
+			admin.DELETE("/admin/trash/:type/:id", trashHandler.PurgeTrashItem)
 		}
 
 		// 活动管理
 		auth.GET("/activities", activityHandler.ListAvailableActivities)       // 所有认证用户可查看活动
+		auth.GET("/activities/:id", activityHandler.GetActivity)               // 活动详情，草稿仅管理员可见
 		admin.GET("/admin/activities", activityHandler.ListActivitiesForAdmin) // 管理员专用查看
-		admin.POST("/activities", activityHandler.CreateActivity)
+		admin.POST("/activities", idempotent, activityHandler.CreateActivity)
 		admin.PUT("/activities/:id", activityHandler.UpdateActivity)
 		admin.DELETE("/activities/:id", activityHandler.DeleteActivity)
 
+		// 活动分类与标签路由
+		auth.GET("/categories", categoryHandler.ListCategories)
+		auth.GET("/tags", categoryHandler.ListTags)
+		admin.GET("/admin/categories/stats", categoryHandler.CategoryStats)
+		admin.POST("/categories", idempotent, categoryHandler.CreateCategory)
+		admin.PUT("/categories/:id", categoryHandler.UpdateCategory)
+		admin.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+
+		// 活动系列路由（管理员权限），单个场次通过活动路由修改和删除
+		admin.GET("/admin/series", seriesHandler.ListSeries)
+		admin.GET("/series/:id", seriesHandler.GetSeries)
+		admin.POST("/series", idempotent, seriesHandler.CreateSeries)
+		admin.PUT("/series/:id", seriesHandler.UpdateSeries)
+		admin.POST("/series/:id/cancel", seriesHandler.CancelSeries)
+
 		// 志愿者相关路由
 		// 管理员权限
 		admin.GET("/volunteers", volunteerHandler.ListVolunteers)
-		admin.POST("/volunteers", volunteerHandler.CreateVolunteer)
+		admin.POST("/volunteers", idempotent, volunteerHandler.CreateVolunteer)
 		admin.PUT("/volunteers/:id", volunteerHandler.UpdateVolunteer)
 		admin.DELETE("/volunteers/:id", volunteerHandler.DeleteVolunteer)
 
+		// 个人信息查看（需要pii:reveal权限，记录审计日志）
+		reveal := auth.Group("", middleware.PermissionRequired(models.PermissionPIIReveal))
+		{
+			reveal.POST("/volunteers/:id/reveal", volunteerHandler.RevealVolunteer)
+			reveal.POST("/registrations/:id/reveal", registrationHandler.RevealRegistration)
+		}
+
 		// 志愿者个人路由（需要志愿者权限）
 		volunteer := auth.Group("", middleware.VolunteerRequired())
 		{
 			// 志愿者个人信息
 			volunteer.GET("/volunteer/my-info", volunteerHandler.GetMyInfo)
 			volunteer.PUT("/volunteer/my-info", volunteerHandler.UpdateMyInfo)
+			volunteer.GET("/volunteer/my-data", privacyHandler.ExportMyData)
+			volunteer.POST("/volunteer/forget", privacyHandler.ForgetMe)
 
 			// 活动报名相关
 			volunteer.GET("/activities/:id/registrations", registrationHandler.ListActivityRegistrations)
 			volunteer.PUT("/registrations/:id/status", registrationHandler.UpdateRegistrationStatus)
-			volunteer.POST("/activities/:id/register", registrationHandler.Register)             // 活动报名
+			volunteer.POST("/activities/:id/register", idempotent, registrationHandler.Register) // 活动报名
 			volunteer.GET("/activities/:id/registration", registrationHandler.GetMyRegistration) // 查询个人报名状态
 		}
 
 		// 通用功能
 		auth.PUT("/auth/password", userHandler.ChangePassword)
+		auth.PUT("/auth/language", userHandler.UpdateLanguage)
 	}
 
 	// Swagger API文档路由
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// 启动服务器
-	if err := r.Run(":8080"); err != nil {
-		log.Fatal("Failed to start server:", err)
+	// 启动服务器，收到退出信号后依次等待请求完成、停止后台任务、关闭数据库
+	srv, certReloader, err := server.New(config.App, r)
+	if err != nil {
+		log.Fatal("Failed to configure server:", err)
+	}
+	runErr := server.Run(srv, certReloader, config.App.ShutdownTimeout, logger)
+
+	for _, worker := range workers {
+		worker.Stop()
+	}
+	tracingCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		logger.Error("刷新链路数据失败", "error", err)
+	}
+	cancel()
+	if err := config.CloseDatabase(); err != nil {
+		logger.Error("关闭数据库连接失败", "error", err)
+	}
+	if runErr != nil {
+		log.Fatal("Server exited with error:", runErr)
 	}
+	logger.Info("服务已退出")
 }
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"seaguard-admin-backend/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatsSource 采集时查询的业务统计数据源
+type StatsSource interface {
+	CountActiveVolunteers(ctx context.Context) (int64, error)
+	OpenActivitySeats(ctx context.Context, now time.Time) ([]models.ActivitySeats, error)
+}
+
+var (
+	activeVolunteersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "volunteers", "active"),
+		"Number of volunteers with active status.",
+		nil, nil,
+	)
+	seatsRemainingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "activity", "seats_remaining"),
+		"Remaining seats of upcoming activities open for registration.",
+		[]string{"activity_id"}, nil,
+	)
+)
+
+// domainCollector 在每次采集时从数据库读取业务状态类指标
+type domainCollector struct {
+	source  StatsSource
+	timeout time.Duration
+	logger  *slog.Logger
+}
+
+// RegisterDomain 注册活跃志愿者人数和活动剩余名额指标
+func RegisterDomain(source StatsSource, logger *slog.Logger) {
+	Registry.MustRegister(&domainCollector{source: source, timeout: 5 * time.Second, logger: logger})
+}
+
+// Describe 实现prometheus.Collector
+func (c *domainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeVolunteersDesc
+	ch <- seatsRemainingDesc
+}
+
+// Collect 实现prometheus.Collector，查询失败时跳过对应指标
+func (c *domainCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if count, err := c.source.CountActiveVolunteers(ctx); err != nil {
+		c.logger.ErrorContext(ctx, "采集活跃志愿者指标失败", "error", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(activeVolunteersDesc, prometheus.GaugeValue, float64(count))
+	}
+
+	seats, err := c.source.OpenActivitySeats(ctx, time.Now())
+	if err != nil {
+		c.logger.ErrorContext(ctx, "采集活动剩余名额指标失败", "error", err)
+		return
+	}
+	for _, s := range seats {
+		ch <- prometheus.MustNewConstMetric(seatsRemainingDesc, prometheus.GaugeValue,
+			float64(s.Remaining), strconv.FormatUint(uint64(s.ActivityID), 10))
+	}
+}
@@ -0,0 +1,120 @@
+// Package metrics 定义Prometheus指标并提供/metrics处理器
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "seaguard"
+
+// Registry 应用使用的指标注册表，包含Go运行时和进程指标
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestDuration 按路由模板统计的HTTP请求耗时
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestsInFlight 正在处理的HTTP请求数
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	})
+
+	// DBQueryDuration 按操作类型和表统计的数据库语句耗时
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Database statement latency by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	// DBQueryErrors 数据库语句错误数，不含记录不存在
+	DBQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "Database statements that returned an error, excluding record not found.",
+	}, []string{"operation", "table"})
+
+	// RegistrationEvents 报名事件计数，event为created、approved、rejected
+	RegistrationEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "registrations",
+		Name:      "events_total",
+		Help:      "Registration lifecycle events by type.",
+	}, []string{"event"})
+
+	// RateLimitRejections 被限流拒绝的请求数
+	RateLimitRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "rate_limited_total",
+		Help:      "Requests rejected by rate limiting, by policy.",
+	}, []string{"policy"})
+
+	// JobRuns 后台任务执行次数，result为success、failure
+	JobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "jobs",
+		Name:      "runs_total",
+		Help:      "Background job runs by job name and result.",
+	}, []string{"job", "result"})
+
+	// JobLastSuccess 后台任务最近一次成功执行的时间
+	JobLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "jobs",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful run of each background job.",
+	}, []string{"job"})
+)
+
+// 报名事件类型
+const (
+	RegistrationCreated  = "created"
+	RegistrationApproved = "approved"
+	RegistrationRejected = "rejected"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		DBQueryDuration,
+		DBQueryErrors,
+		RegistrationEvents,
+		RateLimitRejections,
+		JobRuns,
+		JobLastSuccess,
+	)
+}
+
+// RecordJobRun 记录后台任务的一次执行结果
+func RecordJobRun(job string, err error) {
+	if err != nil {
+		JobRuns.WithLabelValues(job, "failure").Inc()
+		return
+	}
+	JobRuns.WithLabelValues(job, "success").Inc()
+	JobLastSuccess.WithLabelValues(job).SetToCurrentTime()
+}
+
+// Handler 返回以Prometheus文本格式输出指标的HTTP处理器
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
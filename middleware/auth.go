@@ -1,73 +1,75 @@
 package middleware
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
-	"log"
-	"net/http"
+	"log/slog"
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/logging"
 	"seaguard-admin-backend/models"
 	"seaguard-admin-backend/utils"
 	"strings"
 )
 
 type UserGetter interface {
-	GetUserByID(id uint) (*models.User, error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
 }
 
-// AuthMiddleware 认证中间件
-func AuthMiddleware(userService UserGetter) gin.HandlerFunc {
+// AuthMiddleware 认证中间件，认证失败的原因写入logger
+func AuthMiddleware(userService UserGetter, logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "未提供认证token"})
-			c.Abort()
+			utils.AbortWithError(c, errs.ErrTokenMissing)
 			return
 		}
 
 		// 检查Bearer token格式
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "无效的token格式"})
-			c.Abort()
+			utils.AbortWithError(c, errs.ErrTokenMalformed)
 			return
 		}
 
 		// 解析JWT token
 		claims, err := utils.ParseToken(parts[1])
 		if err != nil {
-			log.Printf("Token解析失败: %v", err)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "无效的token"})
-			c.Abort()
+			logger.WarnContext(c.Request.Context(), "Token解析失败", "error", err)
+			utils.AbortWithError(c, errs.ErrTokenInvalid)
 			return
 		}
 
 		// 验证用户是否存在
-		user, err := userService.GetUserByID(claims.UserID)
+		user, err := userService.GetUserByID(c.Request.Context(), claims.UserID)
 		if err != nil {
-			log.Printf("用户验证失败: %v", err)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "无效的用户"})
-			c.Abort()
+			logger.WarnContext(c.Request.Context(), "用户验证失败", "user_id", claims.UserID, "error", err)
+			utils.AbortWithError(c, errs.ErrUserInvalid)
 			return
 		}
 
 		// 检查用户状态
 		if user.Status != "active" {
-			log.Printf("用户 %d 已被禁用", user.ID)
-			c.JSON(http.StatusForbidden, gin.H{"error": "用户已被禁用"})
-			c.Abort()
+			logger.WarnContext(c.Request.Context(), "用户已被禁用", "user_id", user.ID)
+			utils.AbortWithError(c, errs.ErrUserDisabled)
 			return
 		}
 
 		// 验证用户角色是否匹配
 		if user.Role != claims.Role {
-			log.Printf("用户角色不匹配: token中为 %s, 数据库中为 %s", claims.Role, user.Role)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "用户角色验证失败"})
-			c.Abort()
+			logger.WarnContext(c.Request.Context(), "用户角色不匹配", "user_id", user.ID, "token_role", claims.Role, "db_role", user.Role)
+			utils.AbortWithError(c, errs.ErrRoleMismatch)
 			return
 		}
 
 		// 将用户信息存储到上下文中
 		c.Set("userID", user.ID)
 		c.Set("userRole", user.Role)
+		c.Set("userPermissions", user.PermissionList())
+		c.Request = c.Request.WithContext(logging.WithAttrs(c.Request.Context(), slog.Uint64("user_id", uint64(user.ID))))
+		if i18n.IsSupported(user.Language) {
+			c.Set(i18n.ContextKey, user.Language)
+		}
 		c.Next()
 	}
 }
@@ -77,8 +79,7 @@ func AdminRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role := c.GetString("userRole")
 		if role != "admin" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "需要管理员权限"})
-			c.Abort()
+			utils.AbortWithError(c, errs.ErrAdminRequired)
 			return
 		}
 		c.Next()
@@ -90,8 +91,28 @@ func VolunteerRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role := c.GetString("userRole")
 		if role != "volunteer" && role != "admin" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "需要志愿者权限"})
-			c.Abort()
+			utils.AbortWithError(c, errs.ErrVolunteerRequired)
+			return
+		}
+		c.Next()
+	}
+}
+
+// HasPermission 判断当前用户是否拥有指定附加权限
+func HasPermission(c *gin.Context, permission string) bool {
+	for _, p := range c.GetStringSlice("userPermissions") {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionRequired 附加权限验证中间件
+func PermissionRequired(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, permission) {
+			utils.AbortWithError(c, errs.ErrPermissionDenied)
 			return
 		}
 		c.Next()
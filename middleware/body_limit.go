@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit 限制请求体大小，声明的Content-Length超限时直接返回413，
+// 未声明长度的请求在读取超限时由绑定逻辑返回413
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if maxBytes <= 0 {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > maxBytes {
+			utils.AbortWithError(c, errs.ErrBodyTooLarge)
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
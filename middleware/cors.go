@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// corsAllowHeaders 跨域请求允许携带的请求头
+var corsAllowHeaders = []string{"Origin", "Content-Type", "Accept", "Accept-Language", "Authorization", "If-Match", "If-None-Match", "If-Modified-Since", RequestIDHeader, APIKeyHeader, IdempotencyKeyHeader}
+
+// corsExposeHeaders 允许跨域页面读取的响应头
+var corsExposeHeaders = []string{"ETag", "Last-Modified", RequestIDHeader, RateLimitLimitHeader, RateLimitRemainingHeader, RateLimitResetHeader, RetryAfterHeader, IdempotentReplayedHeader}
+
+// CORS 只允许allowedOrigins中的来源跨域访问，其他来源的跨域请求返回403；
+// 来源支持"https://*.example.com"形式的通配符，单独的"*"表示允许所有来源，此时不能携带凭据。
+// allowedOrigins为空时只允许同源访问，maxAge为浏览器缓存预检结果的时长
+func CORS(allowedOrigins []string, allowCredentials bool, maxAge time.Duration) (gin.HandlerFunc, error) {
+	config := cors.Config{
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     corsAllowHeaders,
+		ExposeHeaders:    corsExposeHeaders,
+		AllowCredentials: allowCredentials,
+		AllowWildcard:    true,
+		MaxAge:           maxAge,
+	}
+
+	switch {
+	case slices.Contains(allowedOrigins, "*"):
+		if allowCredentials {
+			return nil, errors.New("允许所有来源时不能同时允许携带凭据")
+		}
+		config.AllowAllOrigins = true
+	case len(allowedOrigins) == 0:
+		config.AllowOriginFunc = func(string) bool { return false }
+	default:
+		config.AllowOrigins = allowedOrigins
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return cors.New(config), nil
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader 客户端为可重试的创建请求生成的唯一Key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader 响应为重放的首次请求结果时设置为true
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// validIdempotencyKey 限制Key的长度和字符集，客户端通常使用UUID
+var validIdempotencyKey = regexp.MustCompile(`^[\x21-\x7e]{1,255}$`)
+
+// replayedHeaders 随响应一起保存并在重放时恢复的响应头
+var replayedHeaders = []string{"Content-Type", "Content-Language", "ETag", "Location"}
+
+// IdempotencyStore 幂等请求记录存储
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, record *models.IdempotencyRecord, staleBefore time.Time) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, key string, statusCode int, headers map[string]string, body string) error
+	Release(ctx context.Context, key string) error
+}
+
+// Idempotency 支持Idempotency-Key请求头，在认证之后使用时按用户区分Key，未认证的请求按客户端IP区分：
+// 首次请求的响应保存ttl时长，期间同一调用方在同一路由上使用相同Key的重试直接重放该响应；
+// Key相同但请求内容不同时返回422，首次请求仍在处理中时返回409。
+// 5xx响应不保存，客户端可以用同一个Key重试；超过lockTimeout仍未完成的首次请求视为已中断。保存记录失败时写入logger
+func Idempotency(store IdempotencyStore, ttl, lockTimeout time.Duration, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if !validIdempotencyKey.MatchString(key) {
+			utils.AbortWithError(c, errs.ErrIdempotencyKeyInvalid)
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				utils.AbortWithError(c, errs.ErrBodyTooLarge.Wrap(err))
+				return
+			}
+			utils.AbortWithError(c, errs.ErrInvalidRequest.Wrap(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID := c.GetUint("userID")
+		caller := strconv.FormatUint(uint64(userID), 10)
+		if userID == 0 {
+			caller = "ip:" + c.ClientIP()
+		}
+		now := time.Now()
+		record := &models.IdempotencyRecord{
+			Key:         digest(caller, c.Request.Method, c.FullPath(), key),
+			UserID:      userID,
+			RequestHash: digest(c.Request.URL.Path, string(body)),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+
+		ctx := c.Request.Context()
+		existing, err := store.Reserve(ctx, record, now.Add(-lockTimeout))
+		if err != nil {
+			utils.AbortWithError(c, err)
+			return
+		}
+		if existing != nil {
+			replay(c, existing, record.RequestHash)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// 请求上下文可能已被取消，保存结果时不受其影响
+		ctx = context.WithoutCancel(ctx)
+		status := recorder.Status()
+		if !recorder.Written() || status >= http.StatusInternalServerError {
+			if err := store.Release(ctx, record.Key); err != nil {
+				logger.WarnContext(ctx, "释放幂等请求记录失败", "error", err)
+			}
+			return
+		}
+
+		headers := make(map[string]string)
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		if err := store.Complete(ctx, record.Key, status, headers, recorder.body.String()); err != nil {
+			logger.WarnContext(ctx, "保存幂等请求响应失败", "error", err)
+		}
+	}
+}
+
+// replay 校验重试请求与首次请求一致后重放首次请求的响应
+func replay(c *gin.Context, existing *models.IdempotencyRecord, requestHash string) {
+	if existing.RequestHash != requestHash {
+		utils.AbortWithError(c, errs.ErrIdempotencyKeyReused)
+		return
+	}
+	if existing.Pending() {
+		utils.AbortWithError(c, errs.ErrIdempotencyInProgress)
+		return
+	}
+
+	for name, value := range existing.Headers {
+		c.Header(name, value)
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Status(existing.StatusCode)
+	_, _ = c.Writer.WriteString(existing.ResponseBody)
+	c.Abort()
+}
+
+// digest 返回各部分拼接后的SHA-256摘要
+func digest(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder 在写出响应的同时保留一份响应体
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
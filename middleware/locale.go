@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"seaguard-admin-backend/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locale 根据Accept-Language请求头确定响应语言，认证后会被用户偏好覆盖
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(i18n.ContextKey, i18n.Negotiate(c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
@@ -0,0 +1,56 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"seaguard-admin-backend/middleware"
+	"seaguard-admin-backend/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestRateLimitAPIKeyRotationDoesNotResetBucket 每次请求换一个未登记的X-API-Key仍计入同一个令牌桶，已登记的Key使用独立的令牌桶
+func TestRateLimitAPIKeyRotationDoesNotResetBucket(t *testing.T) {
+	const (
+		burst      = 3
+		knownKey   = "partner-key"
+		clientAddr = "203.0.113.7:40000"
+	)
+
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), []ratelimit.Policy{
+		{Name: "api-key-default", Key: ratelimit.KeyAPIKey, Requests: 1, PeriodSeconds: 3600, Burst: burst},
+	}, []string{knownKey})
+	router := gin.New()
+	router.Use(middleware.RateLimit(limiter, slog.New(slog.NewTextHandler(io.Discard, nil)), ratelimit.KeyAPIKey))
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	request := func(apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.RemoteAddr = clientAddr
+		req.Header.Set(middleware.APIKeyHeader, apiKey)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	for i := 0; i < burst; i++ {
+		if code := request("random-" + strconv.Itoa(i)); code != http.StatusNoContent {
+			t.Fatalf("第%d个请求返回%d，期望放行", i+1, code)
+		}
+	}
+	if code := request("random-" + strconv.Itoa(burst)); code != http.StatusTooManyRequests {
+		t.Fatalf("换用新的未登记Key后返回%d，期望429", code)
+	}
+	if code := request(""); code != http.StatusTooManyRequests {
+		t.Fatalf("省略X-API-Key后返回%d，期望429", code)
+	}
+	if code := request(knownKey); code != http.StatusNoContent {
+		t.Fatalf("已登记的Key返回%d，期望使用独立的令牌桶放行", code)
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout 为请求上下文设置处理超时，overrides按"方法 路由模板"覆盖默认超时；
+// 超时后数据库操作会被取消，处理器尚未写出响应时返回504
+func Timeout(fallback time.Duration, overrides map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := fallback
+		if override, ok := overrides[c.Request.Method+" "+c.FullPath()]; ok {
+			timeout = override
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			utils.AbortWithError(c, errs.ErrRequestTimeout)
+		}
+	}
+}
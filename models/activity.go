@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// ActivityList 活动列表及其缓存校验信息，ETag和LastModified用于条件请求
+type ActivityList struct {
+	Activities   []Activity
+	ETag         string
+	LastModified time.Time
+}
+
+// 签到时间窗口：活动开始前CheckInOpensBefore开放签到，活动结束时关闭；
+// 未设置结束时间的活动在开始后CheckInClosesAfter关闭
+const (
+	CheckInOpensBefore = time.Hour
+	CheckInClosesAfter = 2 * time.Hour
+)
+
+// CheckInWindow 活动的签到时间窗口
+type CheckInWindow struct {
+	OpensAt  time.Time `json:"opens_at"`
+	ClosesAt time.Time `json:"closes_at"`
+	Open     bool      `json:"open"` // 当前是否处于签到时间内
+}
+
+// CheckInWindow 返回活动在now时的签到时间窗口
+func (a *Activity) CheckInWindow(now time.Time) CheckInWindow {
+	closesAt := a.Date.Add(CheckInClosesAfter)
+	if a.EndDate != nil {
+		closesAt = *a.EndDate
+	}
+	opensAt := a.Date.Add(-CheckInOpensBefore)
+	return CheckInWindow{
+		OpensAt:  opensAt,
+		ClosesAt: closesAt,
+		Open:     !now.Before(opensAt) && now.Before(closesAt),
+	}
+}
+
+// ActivityDetail 活动详情，在活动信息之外附带名额、报名和签到等派生信息
+type ActivityDetail struct {
+	Activity
+	SeatsLeft            int           `json:"seats_left"`
+	PendingCount         int64         `json:"pending_count"` // 已占用名额、等待审核的报名数
+	MyRegistrationStatus string        `json:"my_registration_status,omitempty" enums:"pending,approved,rejected"`
+	CheckIn              CheckInWindow `json:"check_in"`
+	ETag                 string        `json:"-"` // 按当前用户看到的内容计算，仅用于If-None-Match
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Category 活动分类，由管理员维护，如海滩清洁、水下调查、科普教育、巡护
+type Category struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	Name        string    `json:"name" gorm:"size:50;uniqueIndex"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Tag 活动标签，创建或更新活动时按名称自动创建
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Name      string    `json:"name" gorm:"size:32;uniqueIndex"`
+	CreatedAt time.Time `json:"-"`
+}
+
+// NormalizeTag 规范化标签名称：去除首尾空白并转为小写，使大小写不同的同名标签合并
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ActivityFilter 活动列表的筛选条件，零值表示不筛选
+type ActivityFilter struct {
+	CategoryID uint
+	Tag        string
+}
+
+// CacheKey 返回筛选条件在活动列表缓存中的键后缀
+func (f ActivityFilter) CacheKey() string {
+	if f.CategoryID == 0 && f.Tag == "" {
+		return ""
+	}
+	return "?category=" + strconv.FormatUint(uint64(f.CategoryID), 10) + "&tag=" + f.Tag
+}
+
+// CategoryRequest 创建或更新活动分类的请求
+type CategoryRequest struct {
+	Name        string `json:"name" binding:"required,max=50" example:"海滩清洁"`
+	Description string `json:"description" binding:"max=500" example:"清理海滩及近岸垃圾"`
+}
+
+// TagUsage 标签及使用该标签的已发布活动数
+type TagUsage struct {
+	Name       string `json:"name" example:"潜水"`
+	Activities int64  `json:"activities" example:"3"`
+}
+
+// CategoryStats 分类的活动统计，不含草稿和已删除的活动
+type CategoryStats struct {
+	CategoryID            uint    `json:"category_id" example:"1"`
+	Name                  string  `json:"name" example:"海滩清洁"`
+	Activities            int64   `json:"activities" example:"12"`         // 活动总数
+	UpcomingActivities    int64   `json:"upcoming_activities" example:"3"` // 报名中且尚未开始的活动数
+	Capacity              int64   `json:"capacity" example:"360"`          // 名额合计
+	Registered            int64   `json:"registered" example:"298"`        // 已占用名额合计
+	ApprovedRegistrations int64   `json:"approved_registrations" example:"280"`
+	FillRate              float64 `json:"fill_rate" example:"0.83"` // 已占用名额占名额合计的比例
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// SchemaVersion 当前代码对应的表结构版本，模型的表结构发生变化时递增
+const SchemaVersion uint = 6
+
+// SchemaMigration 已应用的表结构版本记录，启动迁移完成后写入
+type SchemaMigration struct {
+	Version   uint      `json:"version" gorm:"primaryKey;autoIncrement:false"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// 就绪检查项状态
+const (
+	CheckStatusOK   = "ok"
+	CheckStatusFail = "fail"
+)
+
+// WorkerStatus 后台任务运行状态
+type WorkerStatus struct {
+	Name    string    `json:"name"`
+	Running bool      `json:"running"`
+	LastRun time.Time `json:"last_run"`
+	Stale   bool      `json:"stale"`  // 超过两个执行间隔没有完成一轮执行
+	Failed  bool      `json:"failed"` // 最近一次执行失败，错误详情只记录在日志中
+}
+
+// HealthCheck 单个就绪检查项的结果，就绪探针不经过认证，失败原因只记录在日志中
+type HealthCheck struct {
+	Name   string `json:"name" example:"database"`
+	Status string `json:"status" example:"ok"`
+}
+
+// Readiness 就绪检查结果，所有检查项通过时Ready为true
+type Readiness struct {
+	Ready   bool           `json:"ready"`
+	Checks  []HealthCheck  `json:"checks"`
+	Workers []WorkerStatus `json:"workers"`
+}
+
+// BuildInfo 构建信息
+type BuildInfo struct {
+	Commit        string `json:"commit" example:"a1b2c3d"`
+	BuildTime     string `json:"build_time" example:"2025-01-01T00:00:00Z"`
+	GoVersion     string `json:"go_version" example:"go1.23.0"`
+	SchemaVersion uint   `json:"schema_version" example:"1"`
+}
+
+// ActivitySeats 可报名活动的剩余名额
+type ActivitySeats struct {
+	ActivityID uint
+	Remaining  int
+}
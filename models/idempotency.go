@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// IdempotencyRecord 幂等请求记录，保存首次请求的摘要和响应，供相同Idempotency-Key的重试请求重放
+type IdempotencyRecord struct {
+	Key          string            `json:"key" gorm:"column:idempotency_key;primaryKey;size:64"` // 用户、路由和客户端Key组合后的摘要
+	UserID       uint              `json:"user_id" gorm:"index"`
+	RequestHash  string            `json:"request_hash" gorm:"size:64"`
+	StatusCode   int               `json:"status_code"` // 为0表示首次请求仍在处理中
+	Headers      map[string]string `json:"headers" gorm:"serializer:json"`
+	ResponseBody string            `json:"-" gorm:"serializer:encrypted"` // 响应可能包含个人信息，加密保存
+	CreatedAt    time.Time         `json:"created_at"`
+	ExpiresAt    time.Time         `json:"expires_at" gorm:"index"`
+}
+
+// Pending 首次请求是否仍在处理中
+func (r *IdempotencyRecord) Pending() bool {
+	return r.StatusCode == 0
+}
@@ -1,18 +1,43 @@
 package models
 
 import (
+	"seaguard-admin-backend/utils/fieldcrypt"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // User 用户模型
 type User struct {
 	ID        uint      `json:"id" gorm:"primarykey"`
 	Username  string    `json:"username" gorm:"unique"`
-	Password  string    `json:"password"`
+	Password  string    `json:"-"`
 	Role      string    `json:"role"` // admin或volunteer
 	Status    string    `json:"status"`
+	Language  string    `json:"language"` // 界面语言偏好：zh或en，为空时按Accept-Language协商
+	Permissions string  `json:"permissions"` // 逗号分隔的附加权限，如pii:view,pii:reveal
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   uint      `json:"version" gorm:"not null;default:1"` // 乐观锁版本号，每次更新加一
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+// 附加权限
+const (
+	PermissionPIIView   = "pii:view"   // 在列表和详情中查看未脱敏的个人信息
+	PermissionPIIReveal = "pii:reveal" // 通过审计接口查看单条记录的完整个人信息
+)
+
+// PermissionList 返回用户的附加权限列表
+func (u *User) PermissionList() []string {
+	var permissions []string
+	for _, p := range strings.Split(u.Permissions, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			permissions = append(permissions, p)
+		}
+	}
+	return permissions
 }
 
 // Activity 活动模型
@@ -20,29 +45,56 @@ type Activity struct {
 	ID          uint      `json:"id" gorm:"primarykey"`
 	Title       string    `json:"title"`
 Date        time.Time `json:"date"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
 	Status      string    `json:"status"`
 	Location    string    `json:"location"`
 	Capacity    int       `json:"capacity"`
-	Registered  int       `json:"registered"`
+	Registered  int       `json:"registered"` // 已占用名额：待审核和已通过的报名
+	MinAge      int       `json:"min_age"` // 最低年龄要求，0表示不限
+	MaxAge      int       `json:"max_age"` // 最高年龄要求，0表示不限
 	Description string    `json:"description"`
+	OrganizerName  string `json:"organizer_name"`  // 活动负责人，供报名者咨询
+	OrganizerPhone string `json:"organizer_phone"`
+	OrganizerEmail string `json:"organizer_email"`
+	Categories  []Category `json:"categories" gorm:"many2many:activity_categories"`
+	Tags        []Tag      `json:"tags" gorm:"many2many:activity_tags"`
+	SeriesID       *uint  `json:"series_id,omitempty" gorm:"uniqueIndex:idx_activity_occurrence"` // 所属活动系列，单独创建的活动为空
+	Occurrence     string `json:"occurrence,omitempty" gorm:"size:10;uniqueIndex:idx_activity_occurrence"` // 系列场次的原定日期，用于避免重复生成
+	SeriesDetached bool   `json:"series_detached,omitempty"` // 场次已单独修改，修改系列时不再随之调整
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	Version     uint      `json:"version" gorm:"not null;default:1"` // 乐观锁版本号，每次更新加一
+	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
 }
 
+// 活动状态
+const (
+	ActivityStatusDraft = "草稿" // 仅管理员可见，发布后转为报名中
+	ActivityStatusOpen  = "报名中"
+	ActivityStatusCancelled = "已取消" // 系列取消或调整后仍有报名的场次
+)
+
+// 志愿者状态
+const (
+	VolunteerStatusActive = "活跃"
+)
+
 // Volunteer 志愿者模型
 type Volunteer struct {
 ID         uint      `json:"id" gorm:"primarykey"`
 UserID     uint      `json:"user_id" gorm:"uniqueIndex;not null"`
 User       User      `json:"user" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 Name       string    `json:"name"`
-Phone      string    `json:"phone"`
+Phone      string    `json:"phone" gorm:"serializer:encrypted"`
 Email      string    `json:"email"`
-Address    string    `json:"address"`
+Address    string    `json:"address" gorm:"serializer:encrypted"`
 Hours      int       `json:"hours"`
 Activities int       `json:"activities"`
 Status     string    `json:"status"`
 CreatedAt  time.Time `json:"created_at"`
 UpdatedAt  time.Time `json:"updated_at"`
+Version    uint      `json:"version" gorm:"not null;default:1"` // 乐观锁版本号，每次更新加一
+DeletedAt  gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
 }
 
 // Registration 报名记录模型
@@ -51,22 +103,96 @@ ID               uint      `json:"id" gorm:"primarykey"`
 ActivityID       uint      `json:"activity_id"`
 UserID           uint      `json:"user_id"` // 添加UserID字段
 Name             string    `json:"name"`
-Phone            string    `json:"phone"`
-IDCard           string    `json:"id_card"`
+Phone            string    `json:"phone" gorm:"serializer:encrypted"`
+DocumentType     string    `json:"document_type"`
+IDCard           string    `json:"id_card" gorm:"serializer:encrypted"` // 证件号码
+IDCardIndex      string    `json:"-" gorm:"index"`                      // 证件号码盲索引，用于重复报名检查
+BirthDate        *time.Time `json:"birth_date,omitempty"`
+Gender           string    `json:"gender"`
 Email            string    `json:"email"`
-EmergencyContact string    `json:"emergency_contact"`
-EmergencyPhone   string    `json:"emergency_phone"`
+EmergencyContact string    `json:"emergency_contact" gorm:"serializer:encrypted"`
+EmergencyPhone   string    `json:"emergency_phone" gorm:"serializer:encrypted"`
 Status           string    `json:"status"`
 CreateTime       time.Time `json:"create_time"`
 UpdatedAt        time.Time `json:"updated_at"`
+Version          uint      `json:"version" gorm:"not null;default:1"` // 乐观锁版本号，每次更新加一
+}
+
+// BeforeSave 保存前更新证件号码盲索引
+func (r *Registration) BeforeSave(tx *gorm.DB) error {
+	r.IDCardIndex = fieldcrypt.BlindIndex(r.IDCard)
+	return nil
 }
 
+// 报名状态
+const (
+	RegistrationPending  = "pending"
+	RegistrationApproved = "approved"
+	RegistrationRejected = "rejected"
+)
+
+// 证件类型
+const (
+	DocumentIDCard        = "id_card"         // 居民身份证（含港澳台居民居住证）
+	DocumentPassport      = "passport"        // 护照
+	DocumentHKMacauPermit = "hk_macau_permit" // 港澳居民来往内地通行证
+	DocumentTaiwanPermit  = "taiwan_permit"   // 台湾居民来往大陆通行证
+)
+
 // RegistrationRequest 活动报名请求
 type RegistrationRequest struct {
 Name            string `json:"name" binding:"required" example:"张三"`
-Phone           string `json:"phone" binding:"required" example:"13800138000"`
-IDCard          string `json:"id_card" binding:"required" example:"110101199001011234"`
+Phone           string `json:"phone" binding:"required,cn_mobile" example:"13800138000"`
+// 证件类型，默认为居民身份证；非身份证时需提供出生日期和性别
+DocumentType    string `json:"document_type" binding:"omitempty,oneof=id_card passport hk_macau_permit taiwan_permit" example:"id_card" enums:"id_card,passport,hk_macau_permit,taiwan_permit"`
+IDCard          string `json:"id_card" binding:"required" example:"110101199001011237"`
+BirthDate       string `json:"birth_date" binding:"omitempty,datetime=2006-01-02" example:"1990-01-01"`
+Gender          string `json:"gender" binding:"omitempty,oneof=male female" example:"male" enums:"male,female"`
 Email           string `json:"email" binding:"required,email" example:"zhangsan@example.com"`
 EmergencyContact string `json:"emergency_contact" binding:"required" example:"李四"`
-EmergencyPhone   string `json:"emergency_phone" binding:"required" example:"13900139000"`
+EmergencyPhone   string `json:"emergency_phone" binding:"required,cn_mobile" example:"13900139000"`
+}
+
+// AuditLog 审计日志模型
+type AuditLog struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	ActorID    uint      `json:"actor_id" gorm:"index"` // 操作人用户ID，系统任务为0
+	Action     string    `json:"action" gorm:"index"`
+	TargetType string    `json:"target_type"`
+	TargetID   uint      `json:"target_id"`
+	Reason     string    `json:"reason"`
+	Detail     string    `json:"detail"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
+
+// 审计操作类型
+const (
+	AuditActionPIIReveal      = "pii.reveal"
+	AuditActionDataExport     = "user.export"
+	AuditActionUserAnonymize  = "user.anonymize"
+	AuditActionRetentionPurge = "retention.purge"
+	AuditActionTrashRestore   = "trash.restore"
+	AuditActionTrashPurge     = "trash.purge"
+)
+
+// 用户状态
+const (
+	UserStatusAnonymized = "anonymized"
+)
+
+// PersonalDataExport 个人数据导出内容
+type PersonalDataExport struct {
+	ExportedAt    time.Time            `json:"exported_at"`
+	User          User                 `json:"user"`
+	Volunteer     *Volunteer           `json:"volunteer,omitempty"`
+	Registrations []RegistrationExport `json:"registrations"`
+	TotalHours    int                  `json:"total_hours"`
+}
+
+// RegistrationExport 导出的报名记录，附带活动信息
+type RegistrationExport struct {
+	Registration
+	ActivityTitle string    `json:"activity_title"`
+	ActivityDate  time.Time `json:"activity_date"`
 }
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// RateLimitBucket 持久化的限流令牌桶，多实例部署时共享限流状态
+type RateLimitBucket struct {
+	Key       string    `json:"key" gorm:"column:bucket_key;primaryKey;size:191"`
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+	FullAt    time.Time `json:"full_at" gorm:"index"` // 令牌补满的时间，此后的记录可以删除
+}
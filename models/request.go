@@ -1,16 +1,28 @@
 package models
 
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"seaguard-admin-backend/utils/idcard"
+
+	"github.com/go-playground/validator/v10"
+)
+
 // RegisterRequest 用户注册请求
 type RegisterRequest struct {
 	// User账号信息
 	Username string `json:"username" binding:"required" example:"john_doe"`
 	Password string `json:"password" binding:"required" example:"your_password"`
 	Role     string `json:"role" binding:"required" example:"volunteer" enums:"admin,volunteer"`
+	Language string `json:"language" example:"zh" enums:"zh,en"`
 
 	// Volunteer个人信息（当role为volunteer时必填）
 	Name    string `json:"name" binding:"required_if=Role volunteer" example:"张三"`
-	Phone   string `json:"phone" binding:"required_if=Role volunteer" example:"13800138000"`
-	Email   string `json:"email" binding:"required_if=Role volunteer,email" example:"zhangsan@example.com"`
+	Phone   string `json:"phone" binding:"required_if=Role volunteer,omitempty,cn_mobile" example:"13800138000"`
+	Email   string `json:"email" binding:"required_if=Role volunteer,omitempty,email" example:"zhangsan@example.com"`
 	Address string `json:"address" binding:"required_if=Role volunteer" example:"北京市海淀区"`
 }
 
@@ -26,12 +38,148 @@ type ChangePasswordRequest struct {
 	NewPassword string `json:"new_password" binding:"required" example:"new_password"`
 }
 
+// UpdateLanguageRequest 更新语言偏好请求
+type UpdateLanguageRequest struct {
+	Language string `json:"language" binding:"required" example:"en" enums:"zh,en"`
+}
+
+// UpdatePermissionsRequest 更新用户附加权限请求
+type UpdatePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"dive,oneof=pii:view pii:reveal" example:"pii:view"`
+}
+
+// RevealRequest 查看完整个人信息请求
+type RevealRequest struct {
+	Reason string `json:"reason" binding:"required,max=200" example:"核对保险投保信息"`
+}
+
+// ForgetMeRequest 注销并匿名化个人数据请求
+type ForgetMeRequest struct {
+	Password string `json:"password" binding:"required" example:"your_password"`
+}
+
 // StatusUpdateRequest 状态更新请求已在response.go中定义
 
 // UpdateVolunteerInfoRequest 更新志愿者信息请求
 type UpdateVolunteerInfoRequest struct {
 	Name    string `json:"name" binding:"required" example:"张三"`
-	Phone   string `json:"phone" binding:"required" example:"13800138000"`
+	Phone   string `json:"phone" binding:"required,cn_mobile" example:"13800138000"`
 	Email   string `json:"email" binding:"required,email" example:"zhangsan@example.com"`
 	Address string `json:"address" binding:"required" example:"北京市海淀区"`
 }
+
+// ActivityRequest 创建/更新活动请求
+type ActivityRequest struct {
+	Title       string     `json:"title" binding:"required" example:"海滩清洁日"`
+	Date        time.Time  `json:"date" binding:"required" example:"2025-06-01T09:00:00+08:00"`
+	EndDate     *time.Time `json:"end_date" example:"2025-06-01T12:00:00+08:00"`
+	Location    string     `json:"location" binding:"required" example:"青岛市第一海水浴场"`
+	Capacity    int        `json:"capacity" binding:"required,min=1" example:"30"`
+	MinAge      int        `json:"min_age" binding:"min=0" example:"18"`
+	MaxAge      int        `json:"max_age" binding:"omitempty,gtefield=MinAge" example:"60"`
+	Description string     `json:"description" example:"清理海滩垃圾，保护海洋环境"`
+
+	OrganizerName  string `json:"organizer_name" example:"王老师"`
+	OrganizerPhone string `json:"organizer_phone" binding:"omitempty,cn_mobile" example:"13900139000"`
+	OrganizerEmail string `json:"organizer_email" binding:"omitempty,email" example:"organizer@example.com"`
+
+	// CategoryIDs 活动所属分类，更新时整体替换
+	CategoryIDs []uint `json:"category_ids" binding:"max=5,dive,min=1" example:"1"`
+	// Tags 自由填写的标签，不存在的标签自动创建，更新时整体替换
+	Tags []string `json:"tags" binding:"max=10,dive,required,max=32" example:"潜水,亲子"`
+
+	// Draft 为true时活动保存为草稿，只有管理员可见；更新时设为false即发布
+	Draft bool `json:"draft" example:"false"`
+}
+
+// RegistrationStatusRequest 报名状态更新请求
+type RegistrationStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending approved rejected" example:"approved" enums:"pending,approved,rejected"`
+}
+
+// 自定义校验标签
+const (
+	TagMobile            = "cn_mobile"
+	TagIDCard            = "cn_idcard"
+	TagActivityDateRange = "activity_date_range"
+	TagDocumentNumber    = "document_number"
+)
+
+var (
+	mobilePattern = regexp.MustCompile(`^1[3-9]\d{9}$`)
+
+	// 非身份证类证件号码格式
+	documentPatterns = map[string]*regexp.Regexp{
+		DocumentPassport:      regexp.MustCompile(`^[A-Za-z0-9]{5,20}$`),
+		DocumentHKMacauPermit: regexp.MustCompile(`^[HMhm]\d{8,10}$`),
+		DocumentTaiwanPermit:  regexp.MustCompile(`^\d{8}(\d{2})?$`),
+	}
+)
+
+// RegisterValidators 注册请求模型使用的自定义校验规则，启动时调用一次
+func RegisterValidators(v *validator.Validate) error {
+	// 校验错误中使用JSON字段名
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	if err := v.RegisterValidation(TagMobile, validateMobile); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation(TagIDCard, validateIDCard); err != nil {
+		return err
+	}
+	v.RegisterStructValidation(validateActivityDateRange, ActivityRequest{})
+	v.RegisterStructValidation(validateRegistrationDocument, RegistrationRequest{})
+	return nil
+}
+
+// validateMobile 校验中国大陆手机号码
+func validateMobile(fl validator.FieldLevel) bool {
+	return mobilePattern.MatchString(fl.Field().String())
+}
+
+// validateIDCard 校验18位居民身份证号码（地区码、出生日期及校验码）
+func validateIDCard(fl validator.FieldLevel) bool {
+	return idcard.Valid(fl.Field().String())
+}
+
+// validateActivityDateRange 校验活动结束时间不早于开始时间
+func validateActivityDateRange(sl validator.StructLevel) {
+	req := sl.Current().Interface().(ActivityRequest)
+	if req.EndDate != nil && req.EndDate.Before(req.Date) {
+		sl.ReportError(req.EndDate, "end_date", "EndDate", TagActivityDateRange, "")
+	}
+}
+
+// validateRegistrationDocument 按证件类型校验证件号码，非身份证时要求提供出生日期和性别
+func validateRegistrationDocument(sl validator.StructLevel) {
+	req := sl.Current().Interface().(RegistrationRequest)
+	if req.IDCard == "" {
+		return
+	}
+
+	if req.DocumentType == "" || req.DocumentType == DocumentIDCard {
+		if !idcard.Valid(req.IDCard) {
+			sl.ReportError(req.IDCard, "id_card", "IDCard", TagIDCard, "")
+		}
+		return
+	}
+
+	if pattern, ok := documentPatterns[req.DocumentType]; ok && !pattern.MatchString(req.IDCard) {
+		sl.ReportError(req.IDCard, "id_card", "IDCard", TagDocumentNumber, req.DocumentType)
+	}
+	if req.BirthDate == "" {
+		sl.ReportError(req.BirthDate, "birth_date", "BirthDate", "required", "")
+	}
+	if req.Gender == "" {
+		sl.ReportError(req.Gender, "gender", "Gender", "required", "")
+	}
+}
@@ -1,41 +1,34 @@
 package models
 
-// Response 基础响应结构
+// Response 统一响应结构
 type Response struct {
-    Message string `json:"message,omitempty"`
-    Error   string `json:"error,omitempty"`
+	Code    string       `json:"code" example:"OK"`
+	Message string       `json:"message" example:"操作成功"`
+	Data    interface{}  `json:"data,omitempty"`
+	Errors  []FieldError `json:"errors,omitempty"`
 }
 
-// LoginResponse 登录响应结构
-type LoginResponse struct {
-    Message string `json:"message"`
-    Token   string `json:"token"`
-    User    User   `json:"user"`
-}
-
-// UsersResponse 用户列表响应结构
-type UsersResponse struct {
-    Users []User `json:"users"`
+// FieldError 字段校验错误详情
+type FieldError struct {
+	Field   string `json:"field" example:"phone"`
+	Rule    string `json:"rule" example:"cn_mobile"`
+	Message string `json:"message" example:"phone必须是有效的手机号码"`
 }
 
-// ActivitiesResponse 活动列表响应结构
-type ActivitiesResponse struct {
-    Code    int        `json:"code"`
-    Message string     `json:"message"`
-    Data    []Activity `json:"data"`
-}
-
-// VolunteersResponse 志愿者列表响应结构
-type VolunteersResponse struct {
-    Volunteers []Volunteer `json:"volunteers"`
+// LoginResponse 登录响应数据
+type LoginResponse struct {
+	Token string    `json:"token"`
+	User  LoginUser `json:"user"`
 }
 
-// RegistrationsResponse 报名记录列表响应结构
-type RegistrationsResponse struct {
-    Registrations []Registration `json:"registrations"`
+// LoginUser 登录响应中的用户信息
+type LoginUser struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
 }
 
 // StatusUpdateRequest 状态更新请求结构
 type StatusUpdateRequest struct {
-    Status string `json:"status" binding:"required"`
+	Status string `json:"status" binding:"required"`
 }
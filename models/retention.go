@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// 保留策略适用的实体
+const (
+	RetentionEntityRegistration = "registration"
+	RetentionEntityAuditLog     = "audit_log"
+)
+
+// 保留期起算点
+const (
+	RetentionAnchorActivityEnd = "activity_end" // 活动结束时间（无结束时间时取活动日期）
+	RetentionAnchorCreatedAt   = "created_at"
+	RetentionAnchorUpdatedAt   = "updated_at"
+)
+
+// retentionFields 各实体允许清除的字段
+var retentionFields = map[string][]string{
+	RetentionEntityRegistration: {"id_card", "phone", "email", "name", "emergency_contact", "emergency_phone", "birth_date"},
+	RetentionEntityAuditLog:     {},
+}
+
+// retentionAnchors 各实体支持的起算点
+var retentionAnchors = map[string][]string{
+	RetentionEntityRegistration: {RetentionAnchorActivityEnd, RetentionAnchorCreatedAt, RetentionAnchorUpdatedAt},
+	RetentionEntityAuditLog:     {RetentionAnchorCreatedAt},
+}
+
+// RetentionRule 数据保留规则：超过保留期后清除指定字段，Field为空时删除整条记录
+type RetentionRule struct {
+	Name      string `json:"name" example:"clear-id-card"`
+	Entity    string `json:"entity" example:"registration"`
+	Field     string `json:"field,omitempty" example:"id_card"`
+	Status    string `json:"status,omitempty" example:"rejected"` // 仅作用于该状态的记录，为空表示不限
+	Anchor    string `json:"anchor" example:"activity_end"`
+	AfterDays int    `json:"after_days" example:"90"`
+}
+
+// Validate 校验规则的实体、字段和起算点是否受支持
+func (r RetentionRule) Validate() error {
+	fields, ok := retentionFields[r.Entity]
+	if !ok {
+		return fmt.Errorf("保留规则%s: 不支持的实体%q", r.Name, r.Entity)
+	}
+	if r.Field != "" && !contains(fields, r.Field) {
+		return fmt.Errorf("保留规则%s: 实体%s不支持清除字段%q", r.Name, r.Entity, r.Field)
+	}
+	if !contains(retentionAnchors[r.Entity], r.Anchor) {
+		return fmt.Errorf("保留规则%s: 实体%s不支持起算点%q", r.Name, r.Entity, r.Anchor)
+	}
+	if r.AfterDays <= 0 {
+		return fmt.Errorf("保留规则%s: after_days必须大于0", r.Name)
+	}
+	return nil
+}
+
+// Cutoff 返回规则在指定时间点的截止时间，起算时间早于截止时间的记录已过保留期
+func (r RetentionRule) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -r.AfterDays)
+}
+
+// RetentionReport 保留策略执行报告
+type RetentionReport struct {
+	DryRun     bool                  `json:"dry_run"`
+	StartedAt  time.Time             `json:"started_at"`
+	FinishedAt time.Time             `json:"finished_at"`
+	Results    []RetentionRuleResult `json:"results"`
+}
+
+// RetentionRuleResult 单条保留规则的执行结果
+type RetentionRuleResult struct {
+	Rule     RetentionRule `json:"rule"`
+	Cutoff   time.Time     `json:"cutoff"`
+	Matched  int64         `json:"matched"`  // 已过保留期的记录数
+	Affected int64         `json:"affected"` // 实际清除或删除的记录数，试运行时为0
+	Error    string        `json:"error,omitempty"`
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// 活动系列状态
+const (
+	SeriesStatusActive    = "active"
+	SeriesStatusCancelled = "cancelled"
+)
+
+// 重复规则的频率
+const (
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+	RecurrenceDates   = "dates" // 仅在Dates列出的日期举行
+)
+
+// OccurrenceDateLayout 场次原定日期的格式
+const OccurrenceDateLayout = "2006-01-02"
+
+// Recurrence 活动系列的重复规则，参照iCalendar RRULE的常用子集：
+// 按周（指定星期几）或按月（指定几号）每Interval个周期重复，Dates为额外举行的日期，Exceptions为跳过的日期
+type Recurrence struct {
+	Frequency  string   `json:"frequency" binding:"required,oneof=weekly monthly dates" enums:"weekly,monthly,dates" example:"weekly"`
+	Interval   int      `json:"interval,omitempty" binding:"min=0,max=52" example:"1"`                  // 每几周或每几个月重复一次，0视为1
+	Weekdays   []int    `json:"weekdays,omitempty" binding:"max=7,dive,min=0,max=6" example:"6"`        // 0为周日；为空时取首场的星期
+	MonthDays  []int    `json:"month_days,omitempty" binding:"max=31,dive,min=1,max=31" example:"1,15"` // 为空时取首场的日期，当月没有该日时跳过
+	Dates      []string `json:"dates,omitempty" binding:"max=366,dive,datetime=2006-01-02" example:"2025-06-01"`
+	Until      string   `json:"until,omitempty" binding:"omitempty,datetime=2006-01-02" example:"2025-12-31"` // 最后可能举行的日期（含），为空表示不结束
+	Exceptions []string `json:"exceptions,omitempty" binding:"max=366,dive,datetime=2006-01-02" example:"2025-10-04"`
+}
+
+// Valid 检查规则是否能产生场次
+func (r *Recurrence) Valid(startAt time.Time) bool {
+	if r.Frequency == RecurrenceDates && len(r.Dates) == 0 {
+		return false
+	}
+	return r.Until == "" || r.Until >= startAt.Format(OccurrenceDateLayout)
+}
+
+// Except 将日期加入跳过列表
+func (r *Recurrence) Except(date string) {
+	if !slices.Contains(r.Exceptions, date) {
+		r.Exceptions = append(r.Exceptions, date)
+	}
+}
+
+// ActivitySeries 重复举行的活动系列，保存场次模板和重复规则，按滚动窗口生成具体的活动场次
+type ActivitySeries struct {
+	ID              uint       `json:"id" gorm:"primarykey"`
+	Title           string     `json:"title"`
+	Location        string     `json:"location"`
+	Capacity        int        `json:"capacity"`
+	MinAge          int        `json:"min_age"`
+	MaxAge          int        `json:"max_age"`
+	Description     string     `json:"description"`
+	OrganizerName   string     `json:"organizer_name"`
+	OrganizerPhone  string     `json:"organizer_phone"`
+	OrganizerEmail  string     `json:"organizer_email"`
+	CategoryIDs     []uint     `json:"category_ids" gorm:"serializer:json"`
+	Tags            []string   `json:"tags" gorm:"serializer:json"`
+	StartAt         time.Time  `json:"start_at"`         // 首场开始时间，同时决定各场次的开始时刻和时区
+	DurationMinutes int        `json:"duration_minutes"` // 每场时长，0表示不设结束时间
+	Recurrence      Recurrence `json:"recurrence" gorm:"serializer:json"`
+	Status          string     `json:"status" enums:"active,cancelled"`
+	GeneratedUntil  time.Time  `json:"generated_until"` // 已生成场次的截止时间
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	Version         uint       `json:"version" gorm:"not null;default:1"` // 乐观锁版本号，每次更新加一
+}
+
+// TableName 指定活动系列的表名
+func (ActivitySeries) TableName() string {
+	return "activity_series"
+}
+
+// Occurrences 返回开始时间在(from, to]内的场次开始时间，按时间排序；场次不早于首场且不晚于Until，跳过Exceptions中的日期
+func (s *ActivitySeries) Occurrences(from, to time.Time) []time.Time {
+	rule := s.Recurrence
+	interval := max(rule.Interval, 1)
+	loc := s.StartAt.Location()
+	first := dateOf(s.StartAt)
+	last := dateOf(to.In(loc))
+	if rule.Until != "" {
+		until, err := time.ParseInLocation(OccurrenceDateLayout, rule.Until, loc)
+		if err != nil {
+			return nil
+		}
+		if until.Before(last) {
+			last = until
+		}
+	}
+
+	weekdays := rule.Weekdays
+	if len(weekdays) == 0 {
+		weekdays = []int{int(first.Weekday())}
+	}
+	monthDays := rule.MonthDays
+	if len(monthDays) == 0 {
+		monthDays = []int{first.Day()}
+	}
+
+	var starts []time.Time
+	for i, day := 0, first; !day.After(last); i, day = i+1, day.AddDate(0, 0, 1) {
+		date := day.Format(OccurrenceDateLayout)
+		matched := slices.Contains(rule.Dates, date)
+		switch rule.Frequency {
+		case RecurrenceWeekly:
+			// 以首场所在周（周日开始）为第0周
+			week := (i + int(first.Weekday())) / 7
+			matched = matched || (week%interval == 0 && slices.Contains(weekdays, int(day.Weekday())))
+		case RecurrenceMonthly:
+			month := (day.Year()-first.Year())*12 + int(day.Month()) - int(first.Month())
+			matched = matched || (month%interval == 0 && slices.Contains(monthDays, day.Day()))
+		}
+		if !matched || slices.Contains(rule.Exceptions, date) {
+			continue
+		}
+
+		start := time.Date(day.Year(), day.Month(), day.Day(),
+			s.StartAt.Hour(), s.StartAt.Minute(), s.StartAt.Second(), 0, loc)
+		if start.After(from) && !start.After(to) {
+			starts = append(starts, start)
+		}
+	}
+	return starts
+}
+
+// dateOf 返回t当天零点
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// SeriesRequest 创建或更新活动系列的请求
+type SeriesRequest struct {
+	Title           string     `json:"title" binding:"required" example:"周六海滩巡护"`
+	StartAt         time.Time  `json:"start_at" binding:"required" example:"2025-06-07T09:00:00+08:00"`
+	DurationMinutes int        `json:"duration_minutes" binding:"min=0,max=1440" example:"180"`
+	Recurrence      Recurrence `json:"recurrence"`
+	Location        string     `json:"location" binding:"required" example:"青岛市第一海水浴场"`
+	Capacity        int        `json:"capacity" binding:"required,min=1" example:"30"`
+	MinAge          int        `json:"min_age" binding:"min=0" example:"18"`
+	MaxAge          int        `json:"max_age" binding:"omitempty,gtefield=MinAge" example:"60"`
+	Description     string     `json:"description" example:"沿海岸线巡查并清理垃圾"`
+
+	OrganizerName  string `json:"organizer_name" example:"王老师"`
+	OrganizerPhone string `json:"organizer_phone" binding:"omitempty,cn_mobile" example:"13900139000"`
+	OrganizerEmail string `json:"organizer_email" binding:"omitempty,email" example:"organizer@example.com"`
+
+	CategoryIDs []uint   `json:"category_ids" binding:"max=5,dive,min=1" example:"1"`
+	Tags        []string `json:"tags" binding:"max=10,dive,required,max=32" example:"巡护"`
+
+	// EffectiveFrom 仅用于更新：此时间之后开始、且未单独修改过的场次按新模板和规则调整，为空时从当前时间起生效
+	EffectiveFrom *time.Time `json:"effective_from,omitempty" example:"2025-07-01T00:00:00+08:00"`
+}
+
+// SeriesDetail 活动系列及其尚未删除的场次
+type SeriesDetail struct {
+	ActivitySeries
+	Occurrences []Activity `json:"occurrences"`
+}
@@ -0,0 +1,15 @@
+package models
+
+// 回收站条目类型，对应接口路径中的{type}参数
+const (
+	TrashTypeUsers      = "users"
+	TrashTypeVolunteers = "volunteers"
+	TrashTypeActivities = "activities"
+)
+
+// Trash 回收站中已软删除的记录
+type Trash struct {
+	Users      []User      `json:"users"`
+	Volunteers []Volunteer `json:"volunteers"`
+	Activities []Activity  `json:"activities"`
+}
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Limiter 按限流维度和路由选择策略，并从存储中取令牌
+type Limiter struct {
+	store    Store
+	defaults map[string]Policy            // 限流维度 -> 默认策略
+	routes   map[string]map[string]Policy // 限流维度 -> "方法 路由模板" -> 策略
+	apiKeys  map[string]struct{}          // 已登记API Key的摘要
+}
+
+// NewLimiter 创建限流器，同一维度下路由策略优先于默认策略，两者都没有时不限流；
+// apiKeys为已登记的API Key，只有这些Key拥有独立的令牌桶
+func NewLimiter(store Store, policies []Policy, apiKeys []string) *Limiter {
+	l := &Limiter{
+		store:    store,
+		defaults: make(map[string]Policy),
+		routes:   make(map[string]map[string]Policy),
+		apiKeys:  make(map[string]struct{}, len(apiKeys)),
+	}
+	for _, key := range apiKeys {
+		l.apiKeys[apiKeyDigest(key)] = struct{}{}
+	}
+	for _, policy := range policies {
+		if len(policy.Routes) == 0 {
+			l.defaults[policy.Key] = policy
+			continue
+		}
+		if l.routes[policy.Key] == nil {
+			l.routes[policy.Key] = make(map[string]Policy)
+		}
+		for _, route := range policy.Routes {
+			l.routes[policy.Key][route] = policy
+		}
+	}
+	return l
+}
+
+// APIKeyIdentity 返回已登记API Key的限流标识，未登记的Key返回false，避免随意变换请求头得到新的令牌桶；
+// 标识只包含摘要，密钥明文不会出现在存储中
+func (l *Limiter) APIKeyIdentity(key string) (string, bool) {
+	digest := apiKeyDigest(key)
+	if _, ok := l.apiKeys[digest]; !ok {
+		return "", false
+	}
+	return "key:" + digest, true
+}
+
+// apiKeyDigest 返回API Key的SHA-256摘要
+func apiKeyDigest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Policy 返回route在keyType维度上适用的策略
+func (l *Limiter) Policy(keyType, route string) (Policy, bool) {
+	if policy, ok := l.routes[keyType][route]; ok {
+		return policy, true
+	}
+	policy, ok := l.defaults[keyType]
+	return policy, ok
+}
+
+// Take 为identity在策略上取一个令牌，每个策略的令牌桶相互独立
+func (l *Limiter) Take(ctx context.Context, policy Policy, identity string) (Result, error) {
+	key := policy.Name + ":" + policy.Key + ":" + identity
+	return l.store.Take(ctx, key, policy.Limit(), time.Now())
+}
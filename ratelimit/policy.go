@@ -0,0 +1,103 @@
+// Package ratelimit 令牌桶限流：按策略对IP、用户或API Key限流，桶状态保存在可替换的存储中
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+)
+
+// 限流维度
+const (
+	KeyIP     = "ip"      // 客户端IP，在认证之前生效
+	KeyUser   = "user"    // 当前登录用户，在认证之后生效
+	KeyAPIKey = "api_key" // 已登记的X-API-Key，未提供或未登记时退回按当前用户限流，未登录时按客户端IP限流
+)
+
+// Policy 限流策略：每PeriodSeconds秒补充Requests个令牌，桶容量为Burst；
+// Routes为"方法 路由模板"列表，为空表示该维度的默认策略
+type Policy struct {
+	Name          string   `json:"name" example:"login"`
+	Routes        []string `json:"routes,omitempty" example:"POST /api/auth/login"`
+	Key           string   `json:"key" example:"ip"`
+	Requests      int      `json:"requests" example:"5"`
+	PeriodSeconds int      `json:"period_seconds" example:"60"`
+	Burst         int      `json:"burst" example:"5"`
+}
+
+// Validate 校验策略的限流维度和速率参数
+func (p Policy) Validate() error {
+	switch p.Key {
+	case KeyIP, KeyUser, KeyAPIKey:
+	default:
+		return fmt.Errorf("限流策略%s: 不支持的限流维度%q", p.Name, p.Key)
+	}
+	if p.Name == "" {
+		return fmt.Errorf("限流策略缺少名称")
+	}
+	if p.Requests <= 0 || p.PeriodSeconds <= 0 {
+		return fmt.Errorf("限流策略%s: requests和period_seconds必须大于0", p.Name)
+	}
+	if p.Burst <= 0 {
+		return fmt.Errorf("限流策略%s: burst必须大于0", p.Name)
+	}
+	return nil
+}
+
+// Limit 返回策略对应的令牌桶参数
+func (p Policy) Limit() Limit {
+	return Limit{
+		Rate:  float64(p.Requests) / float64(p.PeriodSeconds),
+		Burst: p.Burst,
+	}
+}
+
+// Limit 令牌桶参数，Rate为每秒补充的令牌数
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result 一次取令牌的结果
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // 被拒绝时距离下一个令牌可用的时间
+	ResetAfter time.Duration // 距离桶补满的时间
+}
+
+// Bucket 令牌桶状态
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Take 按经过的时间补充令牌后尝试取出一个，返回更新后的桶和结果；
+// 零值的桶视为已满
+func (b Bucket) Take(limit Limit, now time.Time) (Bucket, Result) {
+	burst := float64(limit.Burst)
+	tokens := burst
+	if !b.UpdatedAt.IsZero() {
+		elapsed := now.Sub(b.UpdatedAt).Seconds()
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		tokens = min(burst, b.Tokens+elapsed*limit.Rate)
+	}
+
+	result := Result{Limit: limit.Burst}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - tokens) / limit.Rate)
+	}
+	result.Remaining = int(tokens)
+	result.ResetAfter = secondsToDuration((burst - tokens) / limit.Rate)
+
+	return Bucket{Tokens: tokens, UpdatedAt: now}, result
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval 内存存储清理已补满令牌桶的最小间隔
+const sweepInterval = time.Minute
+
+// Store 令牌桶存储，Take需要对同一个key原子地完成读取、计算和写回
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+type memoryEntry struct {
+	bucket Bucket
+	fullAt time.Time
+}
+
+// memoryStore 进程内存储，适用于单实例部署
+type memoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryStore 创建进程内存储
+func NewMemoryStore() Store {
+	return &memoryStore{entries: make(map[string]memoryEntry)}
+}
+
+// Take 实现Store
+func (s *memoryStore) Take(_ context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	bucket, result := s.entries[key].bucket.Take(limit, now)
+	s.entries[key] = memoryEntry{bucket: bucket, fullAt: now.Add(result.ResetAfter)}
+	return result, nil
+}
+
+// sweep 删除已补满的令牌桶，它们与不存在的桶等价，避免按IP限流时内存无限增长
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	for key, entry := range s.entries {
+		if !now.Before(entry.fullAt) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -1,51 +1,207 @@
 package repository
 
 import (
-	"seaguard-admin-backend/config"
+	"context"
+	"log/slog"
 	"seaguard-admin-backend/models"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // ActivityRepository 活动仓储接口
 type ActivityRepository interface {
-	FindAll() ([]models.Activity, error)
-	Create(activity *models.Activity) error
-	FindByID(id uint) (*models.Activity, error)
-	Update(activity *models.Activity) error
-	Delete(id uint) error
+	FindAll(ctx context.Context, filter models.ActivityFilter) ([]models.Activity, error)
+	FindAvailable(ctx context.Context, now time.Time, filter models.ActivityFilter) ([]models.Activity, error)
+	LastModified(ctx context.Context, now time.Time) (time.Time, error)
+	Create(ctx context.Context, activity *models.Activity) error
+	FindByID(ctx context.Context, id uint) (*models.Activity, error)
+	FindByIDsUnscoped(ctx context.Context, ids []uint) ([]models.Activity, error)
+	FindBySeries(ctx context.Context, seriesID uint, after time.Time) ([]models.Activity, error)
+	OccurrenceKeys(ctx context.Context, seriesID uint) ([]string, error)
+	Update(ctx context.Context, activity *models.Activity) error
+	ReplaceLabels(ctx context.Context, activity *models.Activity) error
+	ReserveSeat(ctx context.Context, id uint) (bool, error)
+	ReleaseSeat(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint) error
+	FindDeleted(ctx context.Context) ([]models.Activity, error)
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, id uint) error
 }
 
-type activityRepository struct{}
+type activityRepository struct {
+	db *gorm.DB
+}
 
 // NewActivityRepository 创建活动仓储实例
-func NewActivityRepository() ActivityRepository {
-	return &activityRepository{}
+func NewActivityRepository(db *gorm.DB, logger *slog.Logger) ActivityRepository {
+	return &activityRepository{db: withLogger(db, logger)}
 }
 
-// FindAll 获取所有活动
-func (r *activityRepository) FindAll() ([]models.Activity, error) {
+// FindAll 获取所有符合筛选条件的活动
+func (r *activityRepository) FindAll(ctx context.Context, filter models.ActivityFilter) ([]models.Activity, error) {
 	var activities []models.Activity
-	err := config.DB.Find(&activities).Error
+	err := r.withLabels(r.db.WithContext(ctx)).Scopes(filtered(filter)).Find(&activities).Error
 	return activities, err
 }
 
+// FindAvailable 获取符合筛选条件的可报名活动：报名中、尚未开始且仍有名额，按开始时间排序
+func (r *activityRepository) FindAvailable(ctx context.Context, now time.Time, filter models.ActivityFilter) ([]models.Activity, error) {
+	var activities []models.Activity
+	err := r.withLabels(r.db.WithContext(ctx)).Scopes(filtered(filter)).
+		Where("status = ? AND date > ? AND registered < capacity", models.ActivityStatusOpen, now).
+		Order("date, id").Find(&activities).Error
+	return activities, err
+}
+
+// withLabels 查询活动时一并加载分类和标签
+func (r *activityRepository) withLabels(db *gorm.DB) *gorm.DB {
+	return db.Preload("Categories", func(db *gorm.DB) *gorm.DB {
+		return db.Order("categories.name")
+	}).Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tags.name")
+	})
+}
+
+// filtered 按分类和标签筛选活动
+func filtered(filter models.ActivityFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.CategoryID != 0 {
+			db = db.Where("activities.id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+				Table("activity_categories").Select("activity_id").Where("category_id = ?", filter.CategoryID))
+		}
+		if filter.Tag != "" {
+			db = db.Where("activities.id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+				Table("activity_tags").Select("activity_tags.activity_id").
+				Joins("JOIN tags ON tags.id = activity_tags.tag_id").Where("tags.name = ?", filter.Tag))
+		}
+		return db
+	}
+}
+
+// LastModified 返回活动列表最近一次发生变化的时间：活动的修改和删除时间，
+// 以及已开始活动的开始时间（活动开始后不再出现在可报名列表中）
+func (r *activityRepository) LastModified(ctx context.Context, now time.Time) (time.Time, error) {
+	db := r.db.WithContext(ctx).Unscoped().Model(&models.Activity{}).Session(&gorm.Session{})
+	var latest time.Time
+	for column, query := range map[string]*gorm.DB{
+		"updated_at": db,
+		"deleted_at": db.Where("deleted_at IS NOT NULL"),
+		"date":       db.Where("date <= ?", now),
+	} {
+		var values []time.Time
+		if err := query.Order(column+" DESC").Limit(1).Pluck(column, &values).Error; err != nil {
+			return time.Time{}, err
+		}
+		if len(values) > 0 && values[0].After(latest) {
+			latest = values[0]
+		}
+	}
+	return latest, nil
+}
+
 // Create 创建活动
-func (r *activityRepository) Create(activity *models.Activity) error {
-	return config.DB.Create(activity).Error
+func (r *activityRepository) Create(ctx context.Context, activity *models.Activity) error {
+	return r.db.WithContext(ctx).Create(activity).Error
 }
 
 // FindByID 根据ID查找活动
-func (r *activityRepository) FindByID(id uint) (*models.Activity, error) {
+func (r *activityRepository) FindByID(ctx context.Context, id uint) (*models.Activity, error) {
 	var activity models.Activity
-	err := config.DB.First(&activity, id).Error
+	err := r.withLabels(r.db.WithContext(ctx)).First(&activity, id).Error
 	return &activity, err
 }
 
-// Update 更新活动
-func (r *activityRepository) Update(activity *models.Activity) error {
-	return config.DB.Save(activity).Error
+// FindByIDsUnscoped 根据ID批量查找活动，包括回收站中的活动，不加载分类和标签
+func (r *activityRepository) FindByIDsUnscoped(ctx context.Context, ids []uint) ([]models.Activity, error) {
+	var activities []models.Activity
+	if len(ids) == 0 {
+		return activities, nil
+	}
+	err := r.db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Find(&activities).Error
+	return activities, err
+}
+
+// FindBySeries 获取系列中开始时间晚于after的场次，按开始时间排序
+func (r *activityRepository) FindBySeries(ctx context.Context, seriesID uint, after time.Time) ([]models.Activity, error) {
+	var activities []models.Activity
+	err := r.withLabels(r.db.WithContext(ctx)).
+		Where("series_id = ? AND date > ?", seriesID, after).
+		Order("date, id").Find(&activities).Error
+	return activities, err
+}
+
+// OccurrenceKeys 获取系列已生成场次的原定日期，包括已删除的场次
+func (r *activityRepository) OccurrenceKeys(ctx context.Context, seriesID uint) ([]string, error) {
+	var keys []string
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.Activity{}).
+		Where("series_id = ?", seriesID).Pluck("occurrence", &keys).Error
+	return keys, err
+}
+
+// Update 按版本号更新活动，版本不一致时返回ErrVersionConflict
+func (r *activityRepository) Update(ctx context.Context, activity *models.Activity) error {
+	return saveVersioned(r.db.WithContext(ctx), activity, &activity.Version)
+}
+
+// ReplaceLabels 以activity.Categories和activity.Tags替换活动已关联的分类和标签，关联的分类和标签须已存在
+func (r *activityRepository) ReplaceLabels(ctx context.Context, activity *models.Activity) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Model(activity).Omit("Categories.*").Association("Categories").Replace(activity.Categories); err != nil {
+		return err
+	}
+	return db.Model(activity).Omit("Tags.*").Association("Tags").Replace(activity.Tags)
+}
+
+// ReserveSeat 占用一个名额，名额已满时返回false。
+// 通过带条件的UPDATE在数据库内完成检查与扣减，适用于所有数据库且无需显式行锁
+func (r *activityRepository) ReserveSeat(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Activity{}).
+		Where("id = ? AND registered < capacity", id).
+		Updates(map[string]interface{}{
+			"registered": gorm.Expr("registered + 1"),
+			"version":    gorm.Expr("version + 1"),
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReleaseSeat 释放一个名额
+func (r *activityRepository) ReleaseSeat(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&models.Activity{}).
+		Where("id = ? AND registered > 0", id).
+		Updates(map[string]interface{}{
+			"registered": gorm.Expr("registered - 1"),
+			"version":    gorm.Expr("version + 1"),
+		}).Error
 }
 
 // Delete 删除活动
-func (r *activityRepository) Delete(id uint) error {
-	return config.DB.Delete(&models.Activity{}, id).Error
+func (r *activityRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Activity{}, id).Error
+}
+
+// FindDeleted 获取回收站中已软删除的活动
+func (r *activityRepository) FindDeleted(ctx context.Context) ([]models.Activity, error) {
+	var activities []models.Activity
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&activities).Error
+	return activities, err
+}
+
+// Restore 从回收站恢复活动
+func (r *activityRepository) Restore(ctx context.Context, id uint) error {
+	return restore(r.db.WithContext(ctx), &models.Activity{}, "id = ?", id)
+}
+
+// Purge 彻底删除回收站中的活动及其分类和标签关联
+func (r *activityRepository) Purge(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	if err := purge(db, &models.Activity{}, id); err != nil {
+		return err
+	}
+	for _, table := range []string{"activity_categories", "activity_tags"} {
+		if err := db.Exec("DELETE FROM "+table+" WHERE activity_id = ?", id).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
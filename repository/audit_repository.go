@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// AuditRepository 审计日志仓储接口
+type AuditRepository interface {
+	Create(ctx context.Context, log *models.AuditLog) error
+	FindRecent(ctx context.Context, limit int) ([]models.AuditLog, error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository 创建审计日志仓储实例
+func NewAuditRepository(db *gorm.DB, logger *slog.Logger) AuditRepository {
+	return &auditRepository{db: withLogger(db, logger)}
+}
+
+// Create 写入审计日志
+func (r *auditRepository) Create(ctx context.Context, log *models.AuditLog) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+// FindRecent 按时间倒序获取最近的审计日志
+func (r *auditRepository) FindRecent(ctx context.Context, limit int) ([]models.AuditLog, error) {
+	var logs []models.AuditLog
+	err := r.db.WithContext(ctx).Order("created_at DESC").Limit(limit).Find(&logs).Error
+	return logs, err
+}
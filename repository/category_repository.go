@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CategoryRepository 活动分类与标签仓储接口
+type CategoryRepository interface {
+	FindAll(ctx context.Context) ([]models.Category, error)
+	FindByID(ctx context.Context, id uint) (*models.Category, error)
+	FindByName(ctx context.Context, name string) (*models.Category, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Category, error)
+	Create(ctx context.Context, category *models.Category) error
+	Update(ctx context.Context, category *models.Category) error
+	Delete(ctx context.Context, id uint) error
+	Stats(ctx context.Context, now time.Time) ([]models.CategoryStats, error)
+	FindOrCreateTags(ctx context.Context, names []string) ([]models.Tag, error)
+	TagUsage(ctx context.Context) ([]models.TagUsage, error)
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository 创建活动分类与标签仓储实例
+func NewCategoryRepository(db *gorm.DB, logger *slog.Logger) CategoryRepository {
+	return &categoryRepository{db: withLogger(db, logger)}
+}
+
+// FindAll 获取所有分类，按名称排序
+func (r *categoryRepository) FindAll(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.WithContext(ctx).Order("name").Find(&categories).Error
+	return categories, err
+}
+
+// FindByID 根据ID查找分类
+func (r *categoryRepository) FindByID(ctx context.Context, id uint) (*models.Category, error) {
+	var category models.Category
+	err := r.db.WithContext(ctx).First(&category, id).Error
+	return &category, err
+}
+
+// FindByName 根据名称查找分类
+func (r *categoryRepository) FindByName(ctx context.Context, name string) (*models.Category, error) {
+	var category models.Category
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&category).Error
+	return &category, err
+}
+
+// FindByIDs 查找ID在ids中的分类，不存在的ID被忽略
+func (r *categoryRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Category, error) {
+	var categories []models.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("id").Find(&categories).Error
+	return categories, err
+}
+
+// Create 创建分类
+func (r *categoryRepository) Create(ctx context.Context, category *models.Category) error {
+	return r.db.WithContext(ctx).Create(category).Error
+}
+
+// Update 更新分类，并刷新所属活动的修改时间，使活动列表的Last-Modified随分类名称变化
+func (r *categoryRepository) Update(ctx context.Context, category *models.Category) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(category).Error; err != nil {
+			return err
+		}
+		return touchCategoryActivities(tx, category.ID, category.UpdatedAt)
+	})
+}
+
+// Delete 删除分类并解除与活动的关联，分类不存在时返回gorm.ErrRecordNotFound
+func (r *categoryRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := touchCategoryActivities(tx, id, time.Now()); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM activity_categories WHERE category_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Category{}, id)
+		if result.Error == nil && result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return result.Error
+	})
+}
+
+// touchCategoryActivities 将分类下活动的修改时间更新为at，不改变活动的版本号
+func touchCategoryActivities(tx *gorm.DB, categoryID uint, at time.Time) error {
+	return tx.Model(&models.Activity{}).
+		Where("id IN (?)", tx.Session(&gorm.Session{NewDB: true}).Table("activity_categories").Select("activity_id").Where("category_id = ?", categoryID)).
+		UpdateColumn("updated_at", at).Error
+}
+
+// Stats 按分类汇总已发布活动的数量、名额和报名情况，没有活动的分类各项为0
+func (r *categoryRepository) Stats(ctx context.Context, now time.Time) ([]models.CategoryStats, error) {
+	var stats []models.CategoryStats
+	err := r.db.WithContext(ctx).Table("categories").
+		Select(`categories.id AS category_id, categories.name AS name,
+			COUNT(activities.id) AS activities,
+			COALESCE(SUM(CASE WHEN activities.status = ? AND activities.date > ? THEN 1 ELSE 0 END), 0) AS upcoming_activities,
+			COALESCE(SUM(activities.capacity), 0) AS capacity,
+			COALESCE(SUM(activities.registered), 0) AS registered,
+			COALESCE(SUM((SELECT COUNT(*) FROM registrations WHERE registrations.activity_id = activities.id AND registrations.status = ?)), 0) AS approved_registrations`,
+			models.ActivityStatusOpen, now, models.RegistrationApproved).
+		Joins("LEFT JOIN activity_categories ON activity_categories.category_id = categories.id").
+		Joins("LEFT JOIN activities ON activities.id = activity_categories.activity_id AND activities.deleted_at IS NULL AND activities.status <> ?",
+			models.ActivityStatusDraft).
+		Group("categories.id, categories.name").
+		Order("categories.name").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// FindOrCreateTags 按名称查找标签，不存在的标签自动创建，返回顺序与names一致
+func (r *categoryRepository) FindOrCreateTags(ctx context.Context, names []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0, len(names))
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	db := r.db.WithContext(ctx)
+	create := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		create = append(create, models.Tag{Name: name, CreatedAt: time.Now()})
+	}
+	// 并发创建同名标签时由唯一索引去重
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&create).Error; err != nil {
+		return nil, err
+	}
+
+	var found []models.Tag
+	if err := db.Where("name IN ?", names).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	byName := make(map[string]models.Tag, len(found))
+	for _, tag := range found {
+		byName[tag.Name] = tag
+	}
+	for _, name := range names {
+		if tag, ok := byName[name]; ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// TagUsage 统计已发布活动使用的标签，按使用次数降序排列，未被使用的标签不返回
+func (r *categoryRepository) TagUsage(ctx context.Context) ([]models.TagUsage, error) {
+	var usage []models.TagUsage
+	err := r.db.WithContext(ctx).Table("tags").
+		Select("tags.name AS name, COUNT(activities.id) AS activities").
+		Joins("JOIN activity_tags ON activity_tags.tag_id = tags.id").
+		Joins("JOIN activities ON activities.id = activity_tags.activity_id AND activities.deleted_at IS NULL AND activities.status <> ?",
+			models.ActivityStatusDraft).
+		Group("tags.id, tags.name").
+		Order("activities DESC, tags.name").
+		Scan(&usage).Error
+	return usage, err
+}
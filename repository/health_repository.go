@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// HealthRepository 健康检查仓储接口
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	SchemaVersion(ctx context.Context) (uint, error)
+}
+
+type healthRepository struct {
+	db *gorm.DB
+}
+
+// NewHealthRepository 创建健康检查仓储实例
+func NewHealthRepository(db *gorm.DB, logger *slog.Logger) HealthRepository {
+	return &healthRepository{db: withLogger(db, logger)}
+}
+
+// Ping 检查数据库连接是否可用
+func (r *healthRepository) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// SchemaVersion 返回数据库中已应用的最高表结构版本，未记录时为0
+func (r *healthRepository) SchemaVersion(ctx context.Context) (uint, error) {
+	var version uint
+	err := r.db.WithContext(ctx).Model(&models.SchemaMigration{}).
+		Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
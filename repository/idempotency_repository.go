@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"seaguard-admin-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository 幂等请求记录仓储接口
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, record *models.IdempotencyRecord, staleBefore time.Time) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, key string, statusCode int, headers map[string]string, body string) error
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository 创建幂等请求记录仓储实例
+func NewIdempotencyRepository(db *gorm.DB, logger *slog.Logger) IdempotencyRepository {
+	return &idempotencyRepository{db: withLogger(db, logger)}
+}
+
+// Reserve 为首次请求占用Key并返回nil；Key已被占用时返回已有记录。
+// 已过期的记录，以及在staleBefore之前创建仍未完成（处理中途进程退出）的记录会被替换
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord, staleBefore time.Time) (*models.IdempotencyRecord, error) {
+	var existing *models.IdempotencyRecord
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var found models.IdempotencyRecord
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("idempotency_key = ?", record.Key).Take(&found).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+		case err != nil:
+			return err
+		case found.ExpiresAt.After(record.CreatedAt) && !(found.Pending() && found.CreatedAt.Before(staleBefore)):
+			existing = &found
+			return nil
+		default:
+			if err := tx.Delete(&found).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(record).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// Complete 保存首次请求的响应
+func (r *idempotencyRepository) Complete(ctx context.Context, key string, statusCode int, headers map[string]string, body string) error {
+	return r.db.WithContext(ctx).Model(&models.IdempotencyRecord{Key: key}).Updates(&models.IdempotencyRecord{
+		StatusCode:   statusCode,
+		Headers:      headers,
+		ResponseBody: body,
+	}).Error
+}
+
+// Release 删除仍在处理中的记录，使客户端可以用同一个Key重试
+func (r *idempotencyRepository) Release(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).Where("idempotency_key = ? AND status_code = 0", key).
+		Delete(&models.IdempotencyRecord{}).Error
+}
+
+// DeleteExpired 删除已过期的记录
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&models.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
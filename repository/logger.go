@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"log/slog"
+	"seaguard-admin-backend/logging"
+
+	"gorm.io/gorm"
+)
+
+// withLogger 使仓储执行的语句日志写入注入的logger，慢查询阈值沿用db上的配置；
+// db未使用slog日志适配器时（如测试中关闭了日志）原样返回
+func withLogger(db *gorm.DB, logger *slog.Logger) *gorm.DB {
+	gormLogger, ok := db.Logger.(*logging.GormLogger)
+	if !ok || logger == nil {
+		return db
+	}
+	return db.Session(&gorm.Session{Logger: gormLogger.WithLogger(logger)})
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/ratelimit"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RateLimitRepository 数据库限流存储，实现ratelimit.Store
+type RateLimitRepository interface {
+	ratelimit.Store
+	DeleteFull(ctx context.Context, now time.Time) (int64, error)
+}
+
+type rateLimitRepository struct {
+	db *gorm.DB
+}
+
+// NewRateLimitRepository 创建数据库限流存储实例
+func NewRateLimitRepository(db *gorm.DB, logger *slog.Logger) RateLimitRepository {
+	return &rateLimitRepository{db: withLogger(db, logger)}
+}
+
+// Take 在事务中读取令牌桶、取出令牌并写回，保证同一个key的并发请求串行计算
+func (r *rateLimitRepository) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	var result ratelimit.Result
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row models.RateLimitBucket
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("bucket_key = ?", key).Take(&row).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		var bucket ratelimit.Bucket
+		bucket, result = ratelimit.Bucket{Tokens: row.Tokens, UpdatedAt: row.UpdatedAt}.Take(limit, now)
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&models.RateLimitBucket{
+			Key:       key,
+			Tokens:    bucket.Tokens,
+			UpdatedAt: bucket.UpdatedAt,
+			FullAt:    now.Add(result.ResetAfter),
+		}).Error
+	})
+	return result, err
+}
+
+// DeleteFull 删除已补满的令牌桶，它们与不存在的桶等价
+func (r *rateLimitRepository) DeleteFull(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("full_at <= ?", now).Delete(&models.RateLimitBucket{})
+	return result.RowsAffected, result.Error
+}
@@ -1,69 +1,137 @@
 package repository
 
 import (
-	"seaguard-admin-backend/config"
+	"context"
+	"log/slog"
 	"seaguard-admin-backend/models"
+
+	"gorm.io/gorm"
 )
 
 // RegistrationRepository 报名记录仓储接口
 type RegistrationRepository interface {
-FindByActivityID(activityID uint) ([]models.Registration, error)
-FindByID(id uint) (*models.Registration, error)
-Create(registration *models.Registration) error
-Update(registration *models.Registration) error
-UpdateStatus(id uint, status string) error
-FindByUserAndActivity(userID, activityID uint) (*models.Registration, error)
-CheckDuplicateRegistration(userID, activityID uint) (bool, error)
+FindByActivityID(ctx context.Context, activityID uint) ([]models.Registration, error)
+FindByUserID(ctx context.Context, userID uint) ([]models.Registration, error)
+FindByID(ctx context.Context, id uint) (*models.Registration, error)
+Create(ctx context.Context, registration *models.Registration) error
+Update(ctx context.Context, registration *models.Registration) error
+UpdateStatus(ctx context.Context, id uint, status string) error
+FindByUserAndActivity(ctx context.Context, userID, activityID uint) (*models.Registration, error)
+CheckDuplicateRegistration(ctx context.Context, userID, activityID uint) (bool, error)
+CheckDuplicateDocument(ctx context.Context, activityID uint, idCardIndex string) (bool, error)
+CountByActivityAndStatus(ctx context.Context, activityID uint, status string) (int64, error)
+CountByActivityID(ctx context.Context, activityID uint) (int64, error)
+ScrubPersonalData(ctx context.Context, userID uint, detach bool) error
+DeleteByActivityID(ctx context.Context, activityID uint) error
 }
 
-type registrationRepository struct{}
+type registrationRepository struct {
+	db *gorm.DB
+}
 
 // NewRegistrationRepository 创建报名记录仓储实例
-func NewRegistrationRepository() RegistrationRepository {
-	return &registrationRepository{}
+func NewRegistrationRepository(db *gorm.DB, logger *slog.Logger) RegistrationRepository {
+	return &registrationRepository{db: withLogger(db, logger)}
 }
 
 // FindByActivityID 获取活动的所有报名记录
-func (r *registrationRepository) FindByActivityID(activityID uint) ([]models.Registration, error) {
+func (r *registrationRepository) FindByActivityID(ctx context.Context, activityID uint) ([]models.Registration, error) {
+	var registrations []models.Registration
+	err := r.db.WithContext(ctx).Where("activity_id = ?", activityID).Find(&registrations).Error
+	return registrations, err
+}
+
+// FindByUserID 获取用户的所有报名记录
+func (r *registrationRepository) FindByUserID(ctx context.Context, userID uint) ([]models.Registration, error) {
 	var registrations []models.Registration
-	err := config.DB.Where("activity_id = ?", activityID).Find(&registrations).Error
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("create_time").Find(&registrations).Error
 	return registrations, err
 }
 
 // FindByID 根据ID查找报名记录
-func (r *registrationRepository) FindByID(id uint) (*models.Registration, error) {
+func (r *registrationRepository) FindByID(ctx context.Context, id uint) (*models.Registration, error) {
 	var registration models.Registration
-	err := config.DB.First(&registration, id).Error
+	err := r.db.WithContext(ctx).First(&registration, id).Error
 	return &registration, err
 }
 
 // Create 创建报名记录
-func (r *registrationRepository) Create(registration *models.Registration) error {
-	return config.DB.Create(registration).Error
+func (r *registrationRepository) Create(ctx context.Context, registration *models.Registration) error {
+	return r.db.WithContext(ctx).Create(registration).Error
 }
 
-// Update 更新报名记录
-func (r *registrationRepository) Update(registration *models.Registration) error {
-	return config.DB.Save(registration).Error
+// Update 按版本号更新报名记录，版本不一致时返回ErrVersionConflict
+func (r *registrationRepository) Update(ctx context.Context, registration *models.Registration) error {
+	return saveVersioned(r.db.WithContext(ctx), registration, &registration.Version)
 }
 
 // UpdateStatus 更新报名状态
-func (r *registrationRepository) UpdateStatus(id uint, status string) error {
-return config.DB.Model(&models.Registration{}).Where("id = ?", id).Update("status", status).Error
+func (r *registrationRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+return r.db.WithContext(ctx).Model(&models.Registration{}).Where("id = ?", id).Update("status", status).Error
 }
 
 // FindByUserAndActivity 查找用户在某个活动的报名记录
-func (r *registrationRepository) FindByUserAndActivity(userID, activityID uint) (*models.Registration, error) {
+func (r *registrationRepository) FindByUserAndActivity(ctx context.Context, userID, activityID uint) (*models.Registration, error) {
     var registration models.Registration
-    err := config.DB.Where("user_id = ? AND activity_id = ?", userID, activityID).First(&registration).Error
+    err := r.db.WithContext(ctx).Where("user_id = ? AND activity_id = ?", userID, activityID).First(&registration).Error
     return &registration, err
 }
 
 // CheckDuplicateRegistration 检查是否重复报名
-func (r *registrationRepository) CheckDuplicateRegistration(userID, activityID uint) (bool, error) {
+func (r *registrationRepository) CheckDuplicateRegistration(ctx context.Context, userID, activityID uint) (bool, error) {
     var count int64
-    err := config.DB.Model(&models.Registration{}).
+    err := r.db.WithContext(ctx).Model(&models.Registration{}).
         Where("user_id = ? AND activity_id = ?", userID, activityID).
         Count(&count).Error
     return count > 0, err
 }
+
+// CheckDuplicateDocument 通过证件号码盲索引检查同一证件是否已报名该活动
+func (r *registrationRepository) CheckDuplicateDocument(ctx context.Context, activityID uint, idCardIndex string) (bool, error) {
+    var count int64
+    err := r.db.WithContext(ctx).Model(&models.Registration{}).
+        Where("activity_id = ? AND id_card_index = ?", activityID, idCardIndex).
+        Count(&count).Error
+    return count > 0, err
+}
+
+// CountByActivityAndStatus 统计活动中处于指定状态的报名数
+func (r *registrationRepository) CountByActivityAndStatus(ctx context.Context, activityID uint, status string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).
+		Where("activity_id = ? AND status = ?", activityID, status).
+		Count(&count).Error
+	return count, err
+}
+
+// CountByActivityID 统计活动的全部报名数，包括已拒绝的报名
+func (r *registrationRepository) CountByActivityID(ctx context.Context, activityID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).Where("activity_id = ?", activityID).Count(&count).Error
+	return count, err
+}
+
+// ScrubPersonalData 清除用户报名记录中的个人信息，detach为true时同时解除与用户的关联
+func (r *registrationRepository) ScrubPersonalData(ctx context.Context, userID uint, detach bool) error {
+	updates := map[string]interface{}{
+		"name":              "",
+		"phone":             "",
+		"id_card":           "",
+		"id_card_index":     "",
+		"email":             "",
+		"emergency_contact": "",
+		"emergency_phone":   "",
+		"birth_date":        nil,
+		"gender":            "",
+		"version":           gorm.Expr("version + 1"),
+	}
+	if detach {
+		updates["user_id"] = 0
+	}
+	return r.db.WithContext(ctx).Model(&models.Registration{}).Where("user_id = ?", userID).Updates(updates).Error
+}
+
+// DeleteByActivityID 删除活动的全部报名记录
+func (r *registrationRepository) DeleteByActivityID(ctx context.Context, activityID uint) error {
+	return r.db.WithContext(ctx).Where("activity_id = ?", activityID).Delete(&models.Registration{}).Error
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"seaguard-admin-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RetentionRepository 数据保留策略仓储接口
+type RetentionRepository interface {
+	CountExpired(ctx context.Context, rule models.RetentionRule, cutoff time.Time) (int64, error)
+	Purge(ctx context.Context, rule models.RetentionRule, cutoff time.Time) (int64, error)
+}
+
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionRepository 创建数据保留策略仓储实例
+func NewRetentionRepository(db *gorm.DB, logger *slog.Logger) RetentionRepository {
+	return &retentionRepository{db: withLogger(db, logger)}
+}
+
+// CountExpired 统计已过保留期且仍需处理的记录数
+func (r *retentionRepository) CountExpired(ctx context.Context, rule models.RetentionRule, cutoff time.Time) (int64, error) {
+	var count int64
+	query, err := expiredScope(r.db.WithContext(ctx), rule, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	err = query.Count(&count).Error
+	return count, err
+}
+
+// Purge 清除已过保留期记录的指定字段，未指定字段时删除整条记录
+func (r *retentionRepository) Purge(ctx context.Context, rule models.RetentionRule, cutoff time.Time) (int64, error) {
+	var affected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query, err := expiredScope(tx, rule, cutoff)
+		if err != nil {
+			return err
+		}
+
+		var result *gorm.DB
+		if rule.Field == "" {
+			result = query.Delete(retentionModel(rule.Entity))
+		} else {
+			// 使用UpdateColumns避免刷新updated_at，以免影响以更新时间为起算点的其他规则
+			result = query.UpdateColumns(clearedColumns(rule.Field))
+		}
+		affected = result.RowsAffected
+		return result.Error
+	})
+	return affected, err
+}
+
+// expiredScope 构造匹配已过保留期记录的查询
+func expiredScope(db *gorm.DB, rule models.RetentionRule, cutoff time.Time) (*gorm.DB, error) {
+	model := retentionModel(rule.Entity)
+	if model == nil {
+		return nil, fmt.Errorf("不支持的保留实体%q", rule.Entity)
+	}
+	query := db.Model(model)
+
+	switch rule.Anchor {
+	case models.RetentionAnchorActivityEnd:
+		query = query.Where("activity_id IN (?)",
+			db.Unscoped().Model(&models.Activity{}).Select("id").Where("COALESCE(end_date, date) < ?", cutoff))
+	case models.RetentionAnchorCreatedAt:
+		query = query.Where(createdColumn(rule.Entity)+" < ?", cutoff)
+	case models.RetentionAnchorUpdatedAt:
+		query = query.Where("updated_at < ?", cutoff)
+	default:
+		return nil, fmt.Errorf("不支持的保留起算点%q", rule.Anchor)
+	}
+
+	if rule.Status != "" {
+		query = query.Where("status = ?", rule.Status)
+	}
+
+	// 已清除的字段不再重复计入
+	if rule.Field != "" {
+		if rule.Field == "birth_date" {
+			query = query.Where("birth_date IS NOT NULL")
+		} else {
+			query = query.Where(fmt.Sprintf("%s IS NOT NULL AND %s <> ''", rule.Field, rule.Field))
+		}
+	}
+	return query, nil
+}
+
+// retentionModel 返回保留实体对应的模型
+func retentionModel(entity string) interface{} {
+	switch entity {
+	case models.RetentionEntityRegistration:
+		return &models.Registration{}
+	case models.RetentionEntityAuditLog:
+		return &models.AuditLog{}
+	}
+	return nil
+}
+
+// createdColumn 返回实体的创建时间列名
+func createdColumn(entity string) string {
+	if entity == models.RetentionEntityRegistration {
+		return "create_time"
+	}
+	return "created_at"
+}
+
+// clearedColumns 返回清除字段时需要更新的列，清除证件号码时同时清除其盲索引
+func clearedColumns(field string) map[string]interface{} {
+	columns := map[string]interface{}{"version": gorm.Expr("version + 1")}
+	if field == "birth_date" {
+		columns["birth_date"] = nil
+		return columns
+	}
+	columns[field] = ""
+	if field == "id_card" {
+		columns["id_card_index"] = ""
+	}
+	return columns
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SeriesRepository 活动系列仓储接口
+type SeriesRepository interface {
+	FindAll(ctx context.Context) ([]models.ActivitySeries, error)
+	FindActive(ctx context.Context) ([]models.ActivitySeries, error)
+	FindByID(ctx context.Context, id uint) (*models.ActivitySeries, error)
+	Create(ctx context.Context, series *models.ActivitySeries) error
+	Update(ctx context.Context, series *models.ActivitySeries) error
+	AddException(ctx context.Context, id uint, date string) error
+	SetGeneratedUntil(ctx context.Context, id uint, until time.Time) error
+}
+
+type seriesRepository struct {
+	db *gorm.DB
+}
+
+// NewSeriesRepository 创建活动系列仓储实例
+func NewSeriesRepository(db *gorm.DB, logger *slog.Logger) SeriesRepository {
+	return &seriesRepository{db: withLogger(db, logger)}
+}
+
+// FindAll 获取所有活动系列，最近创建的在前
+func (r *seriesRepository) FindAll(ctx context.Context) ([]models.ActivitySeries, error) {
+	var series []models.ActivitySeries
+	err := r.db.WithContext(ctx).Order("id DESC").Find(&series).Error
+	return series, err
+}
+
+// FindActive 获取未取消的活动系列
+func (r *seriesRepository) FindActive(ctx context.Context) ([]models.ActivitySeries, error) {
+	var series []models.ActivitySeries
+	err := r.db.WithContext(ctx).Where("status = ?", models.SeriesStatusActive).Order("id").Find(&series).Error
+	return series, err
+}
+
+// FindByID 根据ID查找活动系列
+func (r *seriesRepository) FindByID(ctx context.Context, id uint) (*models.ActivitySeries, error) {
+	var series models.ActivitySeries
+	err := r.db.WithContext(ctx).First(&series, id).Error
+	return &series, err
+}
+
+// Create 创建活动系列
+func (r *seriesRepository) Create(ctx context.Context, series *models.ActivitySeries) error {
+	return r.db.WithContext(ctx).Create(series).Error
+}
+
+// Update 按版本号更新活动系列，版本不一致时返回ErrVersionConflict
+func (r *seriesRepository) Update(ctx context.Context, series *models.ActivitySeries) error {
+	return saveVersioned(r.db.WithContext(ctx), series, &series.Version)
+}
+
+// AddException 将日期加入系列的跳过列表，使该日期不再生成场次；应在事务中调用，避免与其他修改交错
+func (r *seriesRepository) AddException(ctx context.Context, id uint, date string) error {
+	series, err := r.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	series.Recurrence.Except(date)
+	series.UpdatedAt = time.Now()
+	series.Version++
+	return r.db.WithContext(ctx).Model(series).Select("recurrence", "updated_at", "version").Updates(series).Error
+}
+
+// SetGeneratedUntil 记录已生成场次的截止时间，不改变系列的版本号
+func (r *seriesRepository) SetGeneratedUntil(ctx context.Context, id uint, until time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.ActivitySeries{}).Where("id = ?", id).
+		UpdateColumn("generated_until", until).Error
+}
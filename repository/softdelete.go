@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// restore 清除匹配记录的软删除标记，没有已删除的匹配记录时返回gorm.ErrRecordNotFound
+func restore(db *gorm.DB, model interface{}, query string, args ...interface{}) error {
+	result := db.Unscoped().Model(model).Where(query, args...).Where("deleted_at IS NOT NULL").Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// purge 彻底删除已在回收站中的记录，记录不存在或未被软删除时返回gorm.ErrRecordNotFound
+func purge(db *gorm.DB, model interface{}, id uint) error {
+	result := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
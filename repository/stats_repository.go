@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatsRepository 运行指标统计仓储接口
+type StatsRepository interface {
+	CountActiveVolunteers(ctx context.Context) (int64, error)
+	OpenActivitySeats(ctx context.Context, now time.Time) ([]models.ActivitySeats, error)
+}
+
+type statsRepository struct {
+	db *gorm.DB
+}
+
+// NewStatsRepository 创建运行指标统计仓储实例
+func NewStatsRepository(db *gorm.DB, logger *slog.Logger) StatsRepository {
+	return &statsRepository{db: withLogger(db, logger)}
+}
+
+// CountActiveVolunteers 统计状态为活跃的志愿者人数
+func (r *statsRepository) CountActiveVolunteers(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Volunteer{}).
+		Where("status = ?", models.VolunteerStatusActive).Count(&count).Error
+	return count, err
+}
+
+// OpenActivitySeats 查询报名中且在now之后开始的活动的剩余名额，已开始的活动不再计入
+func (r *statsRepository) OpenActivitySeats(ctx context.Context, now time.Time) ([]models.ActivitySeats, error) {
+	var seats []models.ActivitySeats
+	err := r.db.WithContext(ctx).Model(&models.Activity{}).
+		Select("id AS activity_id, capacity - registered AS remaining").
+		Where("status = ? AND date > ?", models.ActivityStatusOpen, now).
+		Scan(&seats).Error
+	return seats, err
+}
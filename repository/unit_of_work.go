@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"gorm.io/gorm"
+)
+
+// Repositories 绑定到同一数据库连接或事务的仓储集合
+type Repositories struct {
+	Users         *UserRepository
+	Activities    ActivityRepository
+	Categories    CategoryRepository
+	Series        SeriesRepository
+	Volunteers    VolunteerRepository
+	Registrations RegistrationRepository
+	Audit         AuditRepository
+}
+
+// NewRepositories 创建绑定到db的仓储集合
+func NewRepositories(db *gorm.DB, logger *slog.Logger) *Repositories {
+	return &Repositories{
+		Users:         NewUserRepository(db, logger),
+		Activities:    NewActivityRepository(db, logger),
+		Categories:    NewCategoryRepository(db, logger),
+		Series:        NewSeriesRepository(db, logger),
+		Volunteers:    NewVolunteerRepository(db, logger),
+		Registrations: NewRegistrationRepository(db, logger),
+		Audit:         NewAuditRepository(db, logger),
+	}
+}
+
+// UnitOfWork 工作单元接口，使多个仓储操作在同一事务中原子地执行
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(repos *Repositories) error) error
+}
+
+type unitOfWork struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewUnitOfWork 创建工作单元实例，事务中的仓储使用logger记录日志
+func NewUnitOfWork(db *gorm.DB, logger *slog.Logger) UnitOfWork {
+	return &unitOfWork{db: db, logger: logger}
+}
+
+// Do 开启事务并以绑定到该事务的仓储集合执行fn，fn返回错误或发生panic时回滚
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos *Repositories) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx, u.logger))
+	})
+}
@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"context"
+	"fmt"
 	"gorm.io/gorm"
+	"log/slog"
 	"seaguard-admin-backend/models"
 )
 
@@ -9,42 +12,72 @@ type UserRepository struct {
 	db *gorm.DB
 }
 
-func NewUserRepository(db *gorm.DB) *UserRepository {
-	return &UserRepository{db: db}
+func NewUserRepository(db *gorm.DB, logger *slog.Logger) *UserRepository {
+	return &UserRepository{db: withLogger(db, logger)}
 }
 
-func (r *UserRepository) Create(user *models.User) error {
-	return r.db.Create(user).Error
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
 }
 
-func (r *UserRepository) FindByUsername(username string) (*models.User, error) {
+func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
 	var user models.User
-	err := r.db.Where("username = ?", username).First(&user).Error
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *UserRepository) Update(user *models.User) error {
-	return r.db.Save(user).Error
+// Update 按版本号更新用户，版本不一致时返回ErrVersionConflict
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	return saveVersioned(r.db.WithContext(ctx), user, &user.Version)
 }
 
-func (r *UserRepository) FindByID(id uint) (*models.User, error) {
+func (r *UserRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
 	var user models.User
-	err := r.db.First(&user, id).Error
+	err := r.db.WithContext(ctx).First(&user, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *UserRepository) List() ([]models.User, error) {
+func (r *UserRepository) List(ctx context.Context) ([]models.User, error) {
 	var users []models.User
-	err := r.db.Find(&users).Error
+	err := r.db.WithContext(ctx).Find(&users).Error
 	return users, err
 }
 
-func (r *UserRepository) Delete(id uint) error {
-	return r.db.Delete(&models.User{}, id).Error
+func (r *UserRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.User{}, id).Error
+}
+
+// FindDeleted 获取回收站中已软删除的用户
+func (r *UserRepository) FindDeleted(ctx context.Context) ([]models.User, error) {
+	var users []models.User
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&users).Error
+	return users, err
+}
+
+// Restore 从回收站恢复用户
+func (r *UserRepository) Restore(ctx context.Context, id uint) error {
+	return restore(r.db.WithContext(ctx), &models.User{}, "id = ?", id)
+}
+
+// Purge 彻底删除回收站中的用户
+func (r *UserRepository) Purge(ctx context.Context, id uint) error {
+	return purge(r.db.WithContext(ctx), &models.User{}, id)
+}
+
+// Anonymize 清除用户账号信息，密码置空后无法再登录
+func (r *UserRepository) Anonymize(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"username":    fmt.Sprintf("anonymized_%d", id),
+		"password":    "",
+		"status":      models.UserStatusAnonymized,
+		"language":    "",
+		"permissions": "",
+		"version":     gorm.Expr("version + 1"),
+	}).Error
 }
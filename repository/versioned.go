@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrVersionConflict 乐观锁冲突：记录在读取后已被其他请求修改或删除
+var ErrVersionConflict = errors.New("record version conflict")
+
+// saveVersioned 以乐观锁方式保存整条记录：仅当数据库中的版本号仍为*version时写入，成功后版本号加一
+func saveVersioned(db *gorm.DB, model interface{}, version *uint) error {
+	current := *version
+	*version = current + 1
+
+	result := db.Model(model).
+		Where("version = ?", current).
+		Select("*").
+		Omit("created_at", "create_time", clause.Associations).
+		Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = current
+	}
+	return result.Error
+}
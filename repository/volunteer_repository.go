@@ -1,59 +1,126 @@
 package repository
 
 import (
-	"seaguard-admin-backend/config"
+	"context"
+	"log/slog"
 	"seaguard-admin-backend/models"
+
+	"gorm.io/gorm"
 )
 
 // VolunteerRepository 志愿者仓储接口
 type VolunteerRepository interface {
-FindAll() ([]models.Volunteer, error)
-Create(volunteer *models.Volunteer) error
-FindByID(id uint) (*models.Volunteer, error)
-FindByUserID(userID uint) (*models.Volunteer, error)
-Update(volunteer *models.Volunteer) error
-Delete(id uint) error
+FindAll(ctx context.Context) ([]models.Volunteer, error)
+Create(ctx context.Context, volunteer *models.Volunteer) error
+FindByID(ctx context.Context, id uint) (*models.Volunteer, error)
+FindByUserID(ctx context.Context, userID uint) (*models.Volunteer, error)
+Update(ctx context.Context, volunteer *models.Volunteer) error
+Delete(ctx context.Context, id uint) error
+FindDeleted(ctx context.Context) ([]models.Volunteer, error)
+Restore(ctx context.Context, id uint) error
+FindDeletedByID(ctx context.Context, id uint) (*models.Volunteer, error)
+DeleteByUserID(ctx context.Context, userID uint) error
+RestoreByUserID(ctx context.Context, userID uint) error
+Purge(ctx context.Context, id uint) error
+PurgeByUserID(ctx context.Context, userID uint) error
+Anonymize(ctx context.Context, userID uint, name, status string) error
 }
 
-type volunteerRepository struct{}
+type volunteerRepository struct {
+	db *gorm.DB
+}
 
 // NewVolunteerRepository 创建志愿者仓储实例
-func NewVolunteerRepository() VolunteerRepository {
-	return &volunteerRepository{}
+func NewVolunteerRepository(db *gorm.DB, logger *slog.Logger) VolunteerRepository {
+	return &volunteerRepository{db: withLogger(db, logger)}
 }
 
 // FindAll 获取所有志愿者
-func (r *volunteerRepository) FindAll() ([]models.Volunteer, error) {
+func (r *volunteerRepository) FindAll(ctx context.Context) ([]models.Volunteer, error) {
 var volunteers []models.Volunteer
-err := config.DB.Preload("User").Find(&volunteers).Error
+err := r.db.WithContext(ctx).Preload("User").Find(&volunteers).Error
 	return volunteers, err
 }
 
 // Create 创建志愿者
-func (r *volunteerRepository) Create(volunteer *models.Volunteer) error {
-	return config.DB.Create(volunteer).Error
+func (r *volunteerRepository) Create(ctx context.Context, volunteer *models.Volunteer) error {
+	return r.db.WithContext(ctx).Create(volunteer).Error
 }
 
 // FindByID 根据ID查找志愿者
-func (r *volunteerRepository) FindByID(id uint) (*models.Volunteer, error) {
+func (r *volunteerRepository) FindByID(ctx context.Context, id uint) (*models.Volunteer, error) {
 var volunteer models.Volunteer
-err := config.DB.Preload("User").First(&volunteer, id).Error
+err := r.db.WithContext(ctx).Preload("User").First(&volunteer, id).Error
 return &volunteer, err
 }
 
 // FindByUserID 根据UserID查找志愿者
-func (r *volunteerRepository) FindByUserID(userID uint) (*models.Volunteer, error) {
+func (r *volunteerRepository) FindByUserID(ctx context.Context, userID uint) (*models.Volunteer, error) {
 var volunteer models.Volunteer
-err := config.DB.Preload("User").Where("user_id = ?", userID).First(&volunteer).Error
+err := r.db.WithContext(ctx).Preload("User").Where("user_id = ?", userID).First(&volunteer).Error
 return &volunteer, err
 }
 
-// Update 更新志愿者
-func (r *volunteerRepository) Update(volunteer *models.Volunteer) error {
-	return config.DB.Save(volunteer).Error
+// Update 按版本号更新志愿者，版本不一致时返回ErrVersionConflict
+func (r *volunteerRepository) Update(ctx context.Context, volunteer *models.Volunteer) error {
+	return saveVersioned(r.db.WithContext(ctx), volunteer, &volunteer.Version)
 }
 
 // Delete 删除志愿者
-func (r *volunteerRepository) Delete(id uint) error {
-	return config.DB.Delete(&models.Volunteer{}, id).Error
+func (r *volunteerRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Volunteer{}, id).Error
+}
+
+// FindDeleted 获取回收站中已软删除的志愿者，关联用户可能同样已被删除
+func (r *volunteerRepository) FindDeleted(ctx context.Context) ([]models.Volunteer, error) {
+	var volunteers []models.Volunteer
+	err := r.db.WithContext(ctx).Unscoped().
+		Preload("User", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&volunteers).Error
+	return volunteers, err
+}
+
+// Restore 从回收站恢复志愿者
+func (r *volunteerRepository) Restore(ctx context.Context, id uint) error {
+	return restore(r.db.WithContext(ctx), &models.Volunteer{}, "id = ?", id)
+}
+
+// FindDeletedByID 根据ID查找回收站中的志愿者
+func (r *volunteerRepository) FindDeletedByID(ctx context.Context, id uint) (*models.Volunteer, error) {
+	var volunteer models.Volunteer
+	err := r.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&volunteer).Error
+	return &volunteer, err
+}
+
+// DeleteByUserID 将用户的志愿者信息移入回收站
+func (r *volunteerRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.Volunteer{}).Error
+}
+
+// RestoreByUserID 恢复用户在回收站中的志愿者信息，没有可恢复的记录时不报错
+func (r *volunteerRepository) RestoreByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.Volunteer{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).Update("deleted_at", nil).Error
+}
+
+// Purge 彻底删除回收站中的志愿者
+func (r *volunteerRepository) Purge(ctx context.Context, id uint) error {
+	return purge(r.db.WithContext(ctx), &models.Volunteer{}, id)
+}
+
+// PurgeByUserID 彻底删除用户的志愿者信息，无论是否已在回收站中
+func (r *volunteerRepository) PurgeByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Unscoped().Where("user_id = ?", userID).Delete(&models.Volunteer{}).Error
+}
+
+// Anonymize 清除用户志愿者信息中的联系方式，并以name替换姓名
+func (r *volunteerRepository) Anonymize(ctx context.Context, userID uint, name, status string) error {
+	return r.db.WithContext(ctx).Model(&models.Volunteer{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+		"name":    name,
+		"phone":   "",
+		"email":   "",
+		"address": "",
+		"status":  status,
+		"version": gorm.Expr("version + 1"),
+	}).Error
 }
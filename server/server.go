@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"seaguard-admin-backend/config"
+)
+
+// New 根据配置创建HTTP服务，配置了证书时同时设置可热加载证书的TLS配置
+func New(cfg *config.Config, handler http.Handler) (*http.Server, *CertReloader, error) {
+	srv := &http.Server{
+		Addr:              cfg.HTTPAddr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+	if cfg.TLSCertFile == "" {
+		return srv, nil, nil
+	}
+
+	reloader, err := NewCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	srv.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+	return srv, reloader, nil
+}
+
+// Run 启动HTTP服务并阻塞，收到SIGINT/SIGTERM后停止接收新连接，
+// 在ShutdownTimeout内等待进行中的请求完成后返回；SIGHUP触发证书重新加载
+func Run(srv *http.Server, reloader *CertReloader, shutdownTimeout time.Duration, logger *slog.Logger) error {
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	if reloader != nil {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+		go func() {
+			for range hup {
+				if err := reloader.Reload(); err != nil {
+					logger.Error("重新加载TLS证书失败，继续使用旧证书", "error", err)
+					continue
+				}
+				logger.Info("TLS证书已重新加载")
+			}
+		}()
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("HTTP服务已启动", "addr", srv.Addr, "tls", reloader != nil)
+		if reloader != nil {
+			serveErr <- srv.ListenAndServeTLS("", "")
+		} else {
+			serveErr <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case sig := <-stop:
+		logger.Info("收到退出信号，开始优雅退出", "signal", sig.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
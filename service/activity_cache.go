@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"seaguard-admin-backend/models"
+	"sync"
+	"time"
+)
+
+// 活动列表缓存的键，带筛选条件的列表在键后附加筛选条件
+const (
+	activityListAll       = "all"
+	activityListAvailable = "available"
+)
+
+// maxActivityCacheEntries 缓存的列表数量上限，标签筛选取值不受限制，超出后新的筛选结果不再缓存
+const maxActivityCacheEntries = 256
+
+// ActivityCache 活动列表的进程内缓存。活动或报名数据变更时由相关服务调用Invalidate失效；
+// 多实例部署时其他实例的写入不会通知本实例，缓存内容最多滞后ttl
+type ActivityCache struct {
+	ttl time.Duration
+
+	mu         sync.RWMutex
+	generation uint64
+	entries    map[string]activityCacheEntry
+}
+
+// activityLoader 从数据库加载列表，expiresAt为列表内容随时间变化（如活动开始）的时间，为零值表示不随时间变化
+type activityLoader func(ctx context.Context, now time.Time) (activities []models.Activity, lastModified, expiresAt time.Time, err error)
+
+type activityCacheEntry struct {
+	list      *models.ActivityList
+	expiresAt time.Time
+}
+
+// NewActivityCache 创建活动列表缓存
+func NewActivityCache(ttl time.Duration) *ActivityCache {
+	return &ActivityCache{ttl: ttl, entries: make(map[string]activityCacheEntry)}
+}
+
+// Invalidate 清空缓存，正在加载中的旧数据也不会再写入
+func (c *ActivityCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	clear(c.entries)
+}
+
+// get 返回未过期的缓存列表，不存在时调用load加载并写入缓存，缓存在ttl和load给出的过期时间中较早者失效
+func (c *ActivityCache) get(ctx context.Context, key string, load activityLoader) (*models.ActivityList, error) {
+	now := time.Now()
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.RUnlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.list, nil
+	}
+
+	activities, lastModified, expiresAt, err := load(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	etag, err := contentETag(activities)
+	if err != nil {
+		return nil, err
+	}
+	list := &models.ActivityList{Activities: activities, ETag: etag, LastModified: lastModified}
+
+	if ttlExpiry := now.Add(c.ttl); expiresAt.IsZero() || ttlExpiry.Before(expiresAt) {
+		expiresAt = ttlExpiry
+	}
+	c.mu.Lock()
+	if _, exists := c.entries[key]; c.generation == generation && (exists || len(c.entries) < maxActivityCacheEntries) {
+		c.entries[key] = activityCacheEntry{list: list, expiresAt: expiresAt}
+	}
+	c.mu.Unlock()
+	return list, nil
+}
+
+// contentETag 以响应数据的摘要作为弱ETag，响应消息随语言变化但数据相同
+func contentETag(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
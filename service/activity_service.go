@@ -1,79 +1,280 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"seaguard-admin-backend/errs"
 	"seaguard-admin-backend/models"
 	"seaguard-admin-backend/repository"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // ActivityService 活动服务接口
 type ActivityService interface {
-GetAllActivities() ([]models.Activity, error)
-GetAvailableActivities() ([]models.Activity, error)
-CreateActivity(activity *models.Activity) error
-UpdateActivity(id uint, activity *models.Activity) error
-DeleteActivity(id uint) error
+	GetAllActivities(ctx context.Context, filter models.ActivityFilter) (*models.ActivityList, error)
+	GetAvailableActivities(ctx context.Context, filter models.ActivityFilter) (*models.ActivityList, error)
+	GetActivityDetail(ctx context.Context, id, userID uint, isAdmin bool) (*models.ActivityDetail, error)
+	CreateActivity(ctx context.Context, activity *models.Activity, draft bool) error
+	UpdateActivity(ctx context.Context, id uint, activity *models.Activity, draft bool, version uint) (*models.Activity, error)
+	DeleteActivity(ctx context.Context, id uint) error
 }
 
 type activityService struct {
-	repo repository.ActivityRepository
+	repo    repository.ActivityRepository
+	regRepo repository.RegistrationRepository
+	uow     repository.UnitOfWork
+	cache   *ActivityCache
+	logger  *slog.Logger
 }
 
 // NewActivityService 创建活动服务实例
-func NewActivityService(repo repository.ActivityRepository) ActivityService {
+func NewActivityService(
+	repo repository.ActivityRepository,
+	regRepo repository.RegistrationRepository,
+	uow repository.UnitOfWork,
+	cache *ActivityCache,
+	logger *slog.Logger,
+) ActivityService {
 	return &activityService{
-		repo: repo,
+		repo:    repo,
+		regRepo: regRepo,
+		uow:     uow,
+		cache:   cache,
+		logger:  logger,
 	}
 }
 
-// GetAllActivities 获取所有活动
-func (s *activityService) GetAllActivities() ([]models.Activity, error) {
-return s.repo.FindAll()
+// GetAllActivities 获取所有符合筛选条件的活动
+func (s *activityService) GetAllActivities(ctx context.Context, filter models.ActivityFilter) (*models.ActivityList, error) {
+	ctx, span := tracer.Start(ctx, "ActivityService.GetAllActivities")
+	defer span.End()
+
+	return s.cache.get(ctx, activityListAll+filter.CacheKey(), func(ctx context.Context, now time.Time) ([]models.Activity, time.Time, time.Time, error) {
+		activities, err := s.repo.FindAll(ctx, filter)
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, err
+		}
+		lastModified, err := s.repo.LastModified(ctx, now)
+		return activities, lastModified, time.Time{}, err
+	})
 }
 
-// GetAvailableActivities 获取可报名活动
-func (s *activityService) GetAvailableActivities() ([]models.Activity, error) {
-    activities, err := s.repo.FindAll()
-    if err != nil {
-        return nil, err
-    }
-    
-    now := time.Now()
-    var availableActivities []models.Activity
-    for _, activity := range activities {
-        // 检查活动状态、日期和容量
-        if activity.Status == "报名中" && 
-           activity.Date.After(now) && 
-           activity.Registered < activity.Capacity {
-            availableActivities = append(availableActivities, activity)
-        }
-    }
-    return availableActivities, nil
+// GetAvailableActivities 获取符合筛选条件的可报名活动：报名中、尚未开始且仍有名额
+func (s *activityService) GetAvailableActivities(ctx context.Context, filter models.ActivityFilter) (*models.ActivityList, error) {
+	ctx, span := tracer.Start(ctx, "ActivityService.GetAvailableActivities")
+	defer span.End()
+
+	return s.cache.get(ctx, activityListAvailable+filter.CacheKey(), func(ctx context.Context, now time.Time) ([]models.Activity, time.Time, time.Time, error) {
+		activities, err := s.repo.FindAvailable(ctx, now, filter)
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, err
+		}
+		lastModified, err := s.repo.LastModified(ctx, now)
+		// 列表按开始时间排序，最早的活动开始时列表内容发生变化
+		var expiresAt time.Time
+		if len(activities) > 0 {
+			expiresAt = activities[0].Date
+		}
+		return activities, lastModified, expiresAt, err
+	})
+}
+
+// GetActivityDetail 获取活动详情及当前用户的报名状态，草稿活动对非管理员视为不存在
+func (s *activityService) GetActivityDetail(ctx context.Context, id, userID uint, isAdmin bool) (*models.ActivityDetail, error) {
+	ctx, span := tracer.Start(ctx, "ActivityService.GetActivityDetail")
+	defer span.End()
+
+	activity, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, errs.ErrActivityNotFound)
+	}
+	if activity.Status == models.ActivityStatusDraft && !isAdmin {
+		return nil, errs.ErrActivityNotFound
+	}
+
+	pending, err := s.regRepo.CountByActivityAndStatus(ctx, id, models.RegistrationPending)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.ActivityDetail{
+		Activity:     *activity,
+		SeatsLeft:    max(activity.Capacity-activity.Registered, 0),
+		PendingCount: pending,
+		CheckIn:      activity.CheckInWindow(time.Now()),
+	}
+	registration, err := s.regRepo.FindByUserAndActivity(ctx, userID, id)
+	if err == nil {
+		detail.MyRegistrationStatus = registration.Status
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// 详情包含当前用户的报名状态和待审核数，这些变化不改变活动版本号，因此以内容摘要作为条件请求的ETag
+	if detail.ETag, err = contentETag(detail); err != nil {
+		return nil, err
+	}
+	return detail, nil
 }
 
-// CreateActivity 创建活动
-func (s *activityService) CreateActivity(activity *models.Activity) error {
-	activity.Status = "报名中"
+// CreateActivity 创建活动，draft为true时保存为草稿；activity.Categories只需填写ID，activity.Tags只需填写名称
+func (s *activityService) CreateActivity(ctx context.Context, activity *models.Activity, draft bool) error {
+	ctx, span := tracer.Start(ctx, "ActivityService.CreateActivity")
+	defer span.End()
+
+	if activity.Date.IsZero() {
+		return errs.ErrActivityDateRequired
+	}
+
+	activity.Status = models.ActivityStatusOpen
+	if draft {
+		activity.Status = models.ActivityStatusDraft
+	}
 	activity.Registered = 0
 	activity.CreatedAt = time.Now()
 	activity.UpdatedAt = time.Now()
-	return s.repo.Create(activity)
+	err := s.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := resolveLabels(ctx, repos, activity); err != nil {
+			return err
+		}
+		return repos.Activities.Create(ctx, activity)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.cache.Invalidate()
+	s.logger.InfoContext(ctx, "活动已创建", "activity_id", activity.ID, "capacity", activity.Capacity, "status", activity.Status)
+	return nil
+}
+
+// UpdateActivity 更新活动的可编辑字段，version不为0时要求与当前版本一致；
+// 草稿的draft为false时发布活动，已有报名的活动不能改回草稿；分类和标签整体替换为activity中的值
+func (s *activityService) UpdateActivity(ctx context.Context, id uint, activity *models.Activity, draft bool, version uint) (*models.Activity, error) {
+	ctx, span := tracer.Start(ctx, "ActivityService.UpdateActivity")
+	defer span.End()
+
+	if activity.Date.IsZero() {
+		return nil, errs.ErrActivityDateRequired
+	}
+
+	existingActivity, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, errs.ErrActivityNotFound)
+	}
+	if err := checkVersion(version, existingActivity.Version); err != nil {
+		return nil, err
+	}
+
+	existingActivity.Title = activity.Title
+	existingActivity.Date = activity.Date
+	existingActivity.EndDate = activity.EndDate
+	existingActivity.Location = activity.Location
+	existingActivity.Capacity = activity.Capacity
+	existingActivity.MinAge = activity.MinAge
+	existingActivity.MaxAge = activity.MaxAge
+	existingActivity.Description = activity.Description
+	existingActivity.OrganizerName = activity.OrganizerName
+	existingActivity.OrganizerPhone = activity.OrganizerPhone
+	existingActivity.OrganizerEmail = activity.OrganizerEmail
+	switch {
+	case draft && existingActivity.Status == models.ActivityStatusOpen:
+		if existingActivity.Registered > 0 {
+			return nil, errs.ErrActivityHasSignups
+		}
+		existingActivity.Status = models.ActivityStatusDraft
+	case !draft && existingActivity.Status == models.ActivityStatusDraft:
+		existingActivity.Status = models.ActivityStatusOpen
+	}
+	existingActivity.Categories = activity.Categories
+	existingActivity.Tags = activity.Tags
+	// 单独修改系列中的场次后，修改系列时不再调整该场次
+	if existingActivity.SeriesID != nil {
+		existingActivity.SeriesDetached = true
+	}
+	existingActivity.UpdatedAt = time.Now()
+	err = s.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := resolveLabels(ctx, repos, existingActivity); err != nil {
+			return err
+		}
+		if err := repos.Activities.Update(ctx, existingActivity); err != nil {
+			return versionConflict(err)
+		}
+		return repos.Activities.ReplaceLabels(ctx, existingActivity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Invalidate()
+	s.logger.InfoContext(ctx, "活动已更新", "activity_id", id, "version", existingActivity.Version, "status", existingActivity.Status)
+	return existingActivity, nil
 }
 
-// UpdateActivity 更新活动
-func (s *activityService) UpdateActivity(id uint, activity *models.Activity) error {
-	existingActivity, err := s.repo.FindByID(id)
+// DeleteActivity 删除活动，删除系列中的场次时将其日期加入系列的跳过列表，避免重新生成
+func (s *activityService) DeleteActivity(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "ActivityService.DeleteActivity")
+	defer span.End()
+
+	err := s.uow.Do(ctx, func(repos *repository.Repositories) error {
+		activity, err := repos.Activities.FindByID(ctx, id)
+		if err != nil {
+			return notFound(err, errs.ErrActivityNotFound)
+		}
+		if err := repos.Activities.Delete(ctx, id); err != nil {
+			return err
+		}
+		if activity.SeriesID == nil {
+			return nil
+		}
+		return repos.Series.AddException(ctx, *activity.SeriesID, activity.Occurrence)
+	})
 	if err != nil {
 		return err
 	}
 
-	activity.ID = id
-	activity.UpdatedAt = time.Now()
-	activity.Registered = existingActivity.Registered
-	return s.repo.Update(activity)
+	s.cache.Invalidate()
+	s.logger.InfoContext(ctx, "活动已移入回收站", "activity_id", id)
+	return nil
 }
 
-// DeleteActivity 删除活动
-func (s *activityService) DeleteActivity(id uint) error {
-	return s.repo.Delete(id)
+// resolveLabels 将活动中只填写了ID的分类和只填写了名称的标签替换为数据库中的记录：
+// 分类必须已存在，标签规范化并去重后按需创建
+func resolveLabels(ctx context.Context, repos *repository.Repositories, activity *models.Activity) error {
+	ids := make([]uint, 0, len(activity.Categories))
+	seenIDs := make(map[uint]bool, len(activity.Categories))
+	for _, category := range activity.Categories {
+		if !seenIDs[category.ID] {
+			seenIDs[category.ID] = true
+			ids = append(ids, category.ID)
+		}
+	}
+	categories, err := repos.Categories.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(categories) != len(ids) {
+		return errs.ErrUnknownCategory
+	}
+
+	names := make([]string, 0, len(activity.Tags))
+	seenNames := make(map[string]bool, len(activity.Tags))
+	for _, tag := range activity.Tags {
+		name := models.NormalizeTag(tag.Name)
+		if name != "" && !seenNames[name] {
+			seenNames[name] = true
+			names = append(names, name)
+		}
+	}
+	tags, err := repos.Categories.FindOrCreateTags(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	activity.Categories = categories
+	activity.Tags = tags
+	return nil
 }
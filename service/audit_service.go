@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+	"time"
+)
+
+// AuditService 审计服务接口
+type AuditService interface {
+	Record(ctx context.Context, entry *models.AuditLog) error
+	ListRecent(ctx context.Context, limit int) ([]models.AuditLog, error)
+}
+
+type auditService struct {
+	repo repository.AuditRepository
+}
+
+// NewAuditService 创建审计服务实例
+func NewAuditService(repo repository.AuditRepository) AuditService {
+	return &auditService{
+		repo: repo,
+	}
+}
+
+// Record 记录一条审计日志
+func (s *auditService) Record(ctx context.Context, entry *models.AuditLog) error {
+	ctx, span := tracer.Start(ctx, "AuditService.Record")
+	defer span.End()
+
+	entry.CreatedAt = time.Now()
+	return s.repo.Create(ctx, entry)
+}
+
+// ListRecent 获取最近的审计日志
+func (s *auditService) ListRecent(ctx context.Context, limit int) ([]models.AuditLog, error) {
+	ctx, span := tracer.Start(ctx, "AuditService.ListRecent")
+	defer span.End()
+
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.repo.FindRecent(ctx, limit)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CategoryService 活动分类与标签服务接口
+type CategoryService interface {
+	ListCategories(ctx context.Context) ([]models.Category, error)
+	CreateCategory(ctx context.Context, req *models.CategoryRequest) (*models.Category, error)
+	UpdateCategory(ctx context.Context, id uint, req *models.CategoryRequest) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id uint) error
+	CategoryStats(ctx context.Context) ([]models.CategoryStats, error)
+	ListTags(ctx context.Context) ([]models.TagUsage, error)
+}
+
+type categoryService struct {
+	repo   repository.CategoryRepository
+	cache  *ActivityCache
+	logger *slog.Logger
+}
+
+// NewCategoryService 创建活动分类服务实例
+func NewCategoryService(repo repository.CategoryRepository, cache *ActivityCache, logger *slog.Logger) CategoryService {
+	return &categoryService{
+		repo:   repo,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// ListCategories 获取所有分类
+func (s *categoryService) ListCategories(ctx context.Context) ([]models.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.ListCategories")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
+
+// CreateCategory 创建分类，名称不能与已有分类重复
+func (s *categoryService) CreateCategory(ctx context.Context, req *models.CategoryRequest) (*models.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.CreateCategory")
+	defer span.End()
+
+	category := &models.Category{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+	if err := s.checkNameAvailable(ctx, category.Name, 0); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "活动分类已创建", "category_id", category.ID, "name", category.Name)
+	return category, nil
+}
+
+// UpdateCategory 更新分类的名称和说明，所属活动的列表缓存随之失效
+func (s *categoryService) UpdateCategory(ctx context.Context, id uint, req *models.CategoryRequest) (*models.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.UpdateCategory")
+	defer span.End()
+
+	category, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, errs.ErrCategoryNotFound)
+	}
+	category.Name = strings.TrimSpace(req.Name)
+	category.Description = req.Description
+	if err := s.checkNameAvailable(ctx, category.Name, id); err != nil {
+		return nil, err
+	}
+	category.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, category); err != nil {
+		return nil, err
+	}
+
+	s.cache.Invalidate()
+	s.logger.InfoContext(ctx, "活动分类已更新", "category_id", id, "name", category.Name)
+	return category, nil
+}
+
+// DeleteCategory 删除分类，已关联的活动保留但不再属于该分类
+func (s *categoryService) DeleteCategory(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "CategoryService.DeleteCategory")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return notFound(err, errs.ErrCategoryNotFound)
+	}
+
+	s.cache.Invalidate()
+	s.logger.InfoContext(ctx, "活动分类已删除", "category_id", id)
+	return nil
+}
+
+// CategoryStats 按分类统计已发布活动的数量、名额和报名情况
+func (s *categoryService) CategoryStats(ctx context.Context) ([]models.CategoryStats, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.CategoryStats")
+	defer span.End()
+
+	stats, err := s.repo.Stats(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for i := range stats {
+		if stats[i].Capacity > 0 {
+			stats[i].FillRate = float64(stats[i].Registered) / float64(stats[i].Capacity)
+		}
+	}
+	return stats, nil
+}
+
+// ListTags 获取已发布活动使用的标签及使用次数
+func (s *categoryService) ListTags(ctx context.Context) ([]models.TagUsage, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.ListTags")
+	defer span.End()
+
+	return s.repo.TagUsage(ctx)
+}
+
+// checkNameAvailable 检查分类名称是否已被ID不为exceptID的分类使用
+func (s *categoryService) checkNameAvailable(ctx context.Context, name string, exceptID uint) error {
+	existing, err := s.repo.FindByName(ctx, name)
+	if err == nil && existing.ID != exceptID {
+		return errs.ErrCategoryNameTaken
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
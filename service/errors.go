@@ -0,0 +1,33 @@
+package service
+
+import (
+	"errors"
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/repository"
+
+	"gorm.io/gorm"
+)
+
+// notFound 将记录不存在错误转换为对应的领域错误，其他错误原样返回
+func notFound(err error, domainErr *errs.Error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainErr.Wrap(err)
+	}
+	return err
+}
+
+// checkVersion 校验客户端通过If-Match提供的版本号，expected为0表示未提供
+func checkVersion(expected, actual uint) error {
+	if expected != 0 && expected != actual {
+		return errs.ErrVersionConflict
+	}
+	return nil
+}
+
+// versionConflict 将乐观锁冲突转换为领域错误，其他错误原样返回
+func versionConflict(err error) error {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return errs.ErrVersionConflict.Wrap(err)
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"seaguard-admin-backend/buildinfo"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+)
+
+// Worker 可报告运行状态的后台任务
+type Worker interface {
+	Status() models.WorkerStatus
+}
+
+// HealthService 健康检查服务接口
+type HealthService interface {
+	Ready(ctx context.Context) *models.Readiness
+	BuildInfo() models.BuildInfo
+}
+
+type healthService struct {
+	repo    repository.HealthRepository
+	workers []Worker
+	logger  *slog.Logger
+}
+
+// NewHealthService 创建健康检查服务实例，workers为需要纳入就绪检查的后台任务
+func NewHealthService(repo repository.HealthRepository, workers []Worker, logger *slog.Logger) HealthService {
+	return &healthService{
+		repo:    repo,
+		workers: workers,
+		logger:  logger,
+	}
+}
+
+// Ready 依次检查数据库连接、表结构版本，以及后台任务是否在运行且按时完成执行；
+// 结果会返回给未认证的调用方，失败原因只写入日志
+func (s *healthService) Ready(ctx context.Context) *models.Readiness {
+	readiness := &models.Readiness{
+		Ready:   true,
+		Workers: make([]models.WorkerStatus, 0, len(s.workers)),
+	}
+	check := func(name string, err error) {
+		result := models.HealthCheck{Name: name, Status: models.CheckStatusOK}
+		if err != nil {
+			result.Status = models.CheckStatusFail
+			readiness.Ready = false
+			s.logger.WarnContext(ctx, "就绪检查未通过", "check", name, "error", err)
+		}
+		readiness.Checks = append(readiness.Checks, result)
+	}
+
+	check("database", s.repo.Ping(ctx))
+	check("schema", s.checkSchema(ctx))
+
+	var workerErr error
+	for _, worker := range s.workers {
+		status := worker.Status()
+		readiness.Workers = append(readiness.Workers, status)
+		if workerErr != nil {
+			continue
+		}
+		// 单次执行失败只在状态和指标中报告，不影响就绪，下一轮可能恢复
+		if !status.Running {
+			workerErr = fmt.Errorf("worker %s is not running", status.Name)
+		} else if status.Stale {
+			workerErr = fmt.Errorf("worker %s has not completed a run within two intervals", status.Name)
+		}
+	}
+	check("workers", workerErr)
+
+	return readiness
+}
+
+// checkSchema 比对数据库中已应用的表结构版本与代码期望的版本
+func (s *healthService) checkSchema(ctx context.Context) error {
+	version, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version != models.SchemaVersion {
+		return fmt.Errorf("schema version %d applied, %d expected", version, models.SchemaVersion)
+	}
+	return nil
+}
+
+// BuildInfo 返回构建信息及当前表结构版本
+func (s *healthService) BuildInfo() models.BuildInfo {
+	return models.BuildInfo{
+		Commit:        buildinfo.CommitOrUnknown(),
+		BuildTime:     buildinfo.BuildTimeOrUnknown(),
+		GoVersion:     runtime.Version(),
+		SchemaVersion: models.SchemaVersion,
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// anonymizedVolunteerName 匿名化后志愿者的显示名称
+const anonymizedVolunteerName = "已注销志愿者"
+
+// PrivacyService 个人数据导出与匿名化服务接口
+type PrivacyService interface {
+	ExportUserData(ctx context.Context, userID uint) (*models.PersonalDataExport, error)
+	ForgetMe(ctx context.Context, userID uint, password string) error
+	AnonymizeUser(ctx context.Context, userID uint) error
+}
+
+type privacyService struct {
+	userRepo      *repository.UserRepository
+	volunteerRepo repository.VolunteerRepository
+	regRepo       repository.RegistrationRepository
+	actRepo       repository.ActivityRepository
+	uow           repository.UnitOfWork
+	logger        *slog.Logger
+}
+
+// NewPrivacyService 创建个人数据服务实例
+func NewPrivacyService(
+	userRepo *repository.UserRepository,
+	volunteerRepo repository.VolunteerRepository,
+	regRepo repository.RegistrationRepository,
+	actRepo repository.ActivityRepository,
+	uow repository.UnitOfWork,
+	logger *slog.Logger,
+) PrivacyService {
+	return &privacyService{
+		userRepo:      userRepo,
+		volunteerRepo: volunteerRepo,
+		regRepo:       regRepo,
+		actRepo:       actRepo,
+		uow:           uow,
+		logger:        logger,
+	}
+}
+
+// ExportUserData 汇总用户的账号、志愿者档案、报名记录和服务时长
+func (s *privacyService) ExportUserData(ctx context.Context, userID uint) (*models.PersonalDataExport, error) {
+	ctx, span := tracer.Start(ctx, "PrivacyService.ExportUserData")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, notFound(err, errs.ErrUserNotFound)
+	}
+
+	export := &models.PersonalDataExport{
+		ExportedAt:    time.Now(),
+		User:          *user,
+		Registrations: []models.RegistrationExport{},
+	}
+
+	volunteer, err := s.volunteerRepo.FindByUserID(ctx, userID)
+	if err == nil {
+		export.Volunteer = volunteer
+		export.TotalHours = volunteer.Hours
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	registrations, err := s.regRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// 一次查询所有报名对应的活动，已移入回收站的活动仍保留标题和日期
+	activityIDs := make([]uint, 0, len(registrations))
+	for _, registration := range registrations {
+		activityIDs = append(activityIDs, registration.ActivityID)
+	}
+	activities, err := s.actRepo.FindByIDsUnscoped(ctx, activityIDs)
+	if err != nil {
+		return nil, err
+	}
+	activityByID := make(map[uint]*models.Activity, len(activities))
+	for i := range activities {
+		activityByID[activities[i].ID] = &activities[i]
+	}
+	for _, registration := range registrations {
+		item := models.RegistrationExport{Registration: registration}
+		if activity, ok := activityByID[registration.ActivityID]; ok {
+			item.ActivityTitle = activity.Title
+			item.ActivityDate = activity.Date
+		}
+		export.Registrations = append(export.Registrations, item)
+	}
+
+	s.logger.InfoContext(ctx, "个人数据已导出", "target_user_id", userID, "registrations", len(export.Registrations))
+	return export, nil
+}
+
+// ForgetMe 验证密码后匿名化当前用户的个人数据
+func (s *privacyService) ForgetMe(ctx context.Context, userID uint, password string) error {
+	ctx, span := tracer.Start(ctx, "PrivacyService.ForgetMe")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return notFound(err, errs.ErrUserNotFound)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return errs.ErrPasswordIncorrect
+	}
+	return s.AnonymizeUser(ctx, userID)
+}
+
+// AnonymizeUser 清除用户的个人信息，保留报名状态和服务时长等统计数据
+func (s *privacyService) AnonymizeUser(ctx context.Context, userID uint) error {
+	ctx, span := tracer.Start(ctx, "PrivacyService.AnonymizeUser")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return notFound(err, errs.ErrUserNotFound)
+	}
+	if user.Status == models.UserStatusAnonymized {
+		return nil
+	}
+
+	err = s.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.Registrations.ScrubPersonalData(ctx, userID, false); err != nil {
+			return err
+		}
+		if err := repos.Volunteers.Anonymize(ctx, userID, anonymizedVolunteerName, models.UserStatusAnonymized); err != nil {
+			return err
+		}
+		return repos.Users.Anonymize(ctx, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "用户个人数据已匿名化", "target_user_id", userID)
+	return nil
+}
@@ -1,135 +1,249 @@
 package service
 
 import (
-"errors"
-"seaguard-admin-backend/config"
+"context"
+"log/slog"
+"seaguard-admin-backend/errs"
+"seaguard-admin-backend/metrics"
 "seaguard-admin-backend/models"
 "seaguard-admin-backend/repository"
+"seaguard-admin-backend/utils/fieldcrypt"
+"seaguard-admin-backend/utils/idcard"
 "time"
 )
 
 // RegistrationService 报名服务接口
 type RegistrationService interface {
-GetActivityRegistrations(activityID uint) ([]models.Registration, error)
-UpdateRegistrationStatus(id uint, status string) error
-CreateRegistration(userID uint, registration *models.Registration) error
-GetUserRegistration(userID, activityID uint) (*models.Registration, error)
+GetActivityRegistrations(ctx context.Context, activityID uint) ([]models.Registration, error)
+UpdateRegistrationStatus(ctx context.Context, id uint, status string, version uint) (*models.Registration, error)
+CreateRegistration(ctx context.Context, userID uint, registration *models.Registration) error
+GetUserRegistration(ctx context.Context, userID, activityID uint) (*models.Registration, error)
+GetRegistration(ctx context.Context, id uint) (*models.Registration, error)
 }
 
 type registrationService struct {
 	regRepo repository.RegistrationRepository
 	actRepo repository.ActivityRepository
+	uow     repository.UnitOfWork
+	cache   *ActivityCache
+	logger  *slog.Logger
 }
 
 // NewRegistrationService 创建报名服务实例
 func NewRegistrationService(
 	regRepo repository.RegistrationRepository,
 	actRepo repository.ActivityRepository,
+	uow repository.UnitOfWork,
+	cache *ActivityCache,
+	logger *slog.Logger,
 ) RegistrationService {
 	return &registrationService{
 		regRepo: regRepo,
 		actRepo: actRepo,
+		uow:     uow,
+		cache:   cache,
+		logger:  logger,
 	}
 }
 
 // GetActivityRegistrations 获取活动的所有报名记录
-func (s *registrationService) GetActivityRegistrations(activityID uint) ([]models.Registration, error) {
-	return s.regRepo.FindByActivityID(activityID)
+func (s *registrationService) GetActivityRegistrations(ctx context.Context, activityID uint) ([]models.Registration, error) {
+	ctx, span := tracer.Start(ctx, "RegistrationService.GetActivityRegistrations")
+	defer span.End()
+
+	return s.regRepo.FindByActivityID(ctx, activityID)
 }
 
-// UpdateRegistrationStatus 更新报名状态
-func (s *registrationService) UpdateRegistrationStatus(id uint, status string) error {
-	registration, err := s.regRepo.FindByID(id)
+// UpdateRegistrationStatus 更新报名状态，version不为0时要求与当前版本一致；
+// 拒绝报名时释放名额，重新通过或转为待审核时需要重新占用名额，报名记录与名额在同一事务中更新
+func (s *registrationService) UpdateRegistrationStatus(ctx context.Context, id uint, status string, version uint) (*models.Registration, error) {
+	ctx, span := tracer.Start(ctx, "RegistrationService.UpdateRegistrationStatus")
+	defer span.End()
+
+	registration, err := s.regRepo.FindByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, notFound(err, errs.ErrRegistrationNotFound)
+	}
+	if err := checkVersion(version, registration.Version); err != nil {
+		return nil, err
 	}
 
 	oldStatus := registration.Status
 	registration.Status = status
 	registration.UpdatedAt = time.Now()
 
-	err = s.regRepo.Update(registration)
-	if err != nil {
-		return err
-	}
-
-	// 更新活动报名人数
-	if status == "已通过" && oldStatus != "已通过" {
-		activity, err := s.actRepo.FindByID(registration.ActivityID)
-		if err != nil {
-			return err
+	err = s.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if oldStatus == models.RegistrationRejected && status != models.RegistrationRejected {
+			reserved, err := repos.Activities.ReserveSeat(ctx, registration.ActivityID)
+			if err != nil {
+				return err
+			}
+			if !reserved {
+				return errs.ErrActivityFull
+			}
+		} else if oldStatus != models.RegistrationRejected && status == models.RegistrationRejected {
+			if err := repos.Activities.ReleaseSeat(ctx, registration.ActivityID); err != nil {
+				return err
+			}
 		}
 
-		activity.Registered++
-		err = s.actRepo.Update(activity)
-		if err != nil {
-			return err
-		}
-	} else if oldStatus == "已通过" && status != "已通过" {
-		activity, err := s.actRepo.FindByID(registration.ActivityID)
-		if err != nil {
-			return err
-		}
+		return versionConflict(repos.Registrations.Update(ctx, registration))
+	})
+	if err != nil {
+		registration.Status = oldStatus
+		return nil, err
+	}
 
-		activity.Registered--
-		err = s.actRepo.Update(activity)
-		if err != nil {
-			return err
+	if status != oldStatus {
+		// 状态变化可能占用或释放名额
+		s.cache.Invalidate()
+		s.logger.InfoContext(ctx, "报名状态已更新", "registration_id", id, "activity_id", registration.ActivityID,
+			"from", oldStatus, "to", status)
+		switch status {
+		case models.RegistrationApproved:
+			metrics.RegistrationEvents.WithLabelValues(metrics.RegistrationApproved).Inc()
+		case models.RegistrationRejected:
+			metrics.RegistrationEvents.WithLabelValues(metrics.RegistrationRejected).Inc()
 		}
 	}
-
-return nil
+	return registration, nil
 }
 
 // CreateRegistration 创建报名记录
-func (s *registrationService) CreateRegistration(userID uint, registration *models.Registration) error {
+func (s *registrationService) CreateRegistration(ctx context.Context, userID uint, registration *models.Registration) error {
+    ctx, span := tracer.Start(ctx, "RegistrationService.CreateRegistration")
+    defer span.End()
+
     // 检查活动是否存在及可报名
-    activity, err := s.actRepo.FindByID(registration.ActivityID)
+    activity, err := s.actRepo.FindByID(ctx, registration.ActivityID)
     if err != nil {
-        return err
+        return notFound(err, errs.ErrActivityNotFound)
+    }
+    // 草稿活动对志愿者不可见
+    if activity.Status == models.ActivityStatusDraft {
+        return errs.ErrActivityNotFound
     }
     
-    if activity.Status != "进行中" {
-        return errors.New("活动不在报名阶段")
+    if activity.Status != models.ActivityStatusOpen {
+        return errs.ErrActivityNotOpen
     }
     
+    // 名额已满时提前返回，实际占用在事务中通过条件更新完成
     if activity.Registered >= activity.Capacity {
-        return errors.New("活动名额已满")
+        return errs.ErrActivityFull
     }
 
-    // 检查是否重复报名
-    isDuplicate, err := s.regRepo.CheckDuplicateRegistration(userID, registration.ActivityID)
-    if err != nil {
+    // 根据证件信息检查年龄要求
+    if err := applyHolderAttributes(registration); err != nil {
         return err
     }
-    if isDuplicate {
-        return errors.New("already registered")
+    if err := checkAgeEligibility(activity, registration); err != nil {
+        return err
     }
 
     // 设置报名记录属性
     registration.UserID = userID
     registration.CreateTime = time.Now()
     registration.UpdatedAt = time.Now()
-    registration.Status = "pending"
+    registration.Status = models.RegistrationPending
 
-    // 开启事务
-    tx := config.DB.Begin()
-    defer func() {
-        if r := recover(); r != nil {
-            tx.Rollback()
+    err = s.uow.Do(ctx, func(repos *repository.Repositories) error {
+        // 先占用名额：条件更新使事务从一开始就持有写锁，并发报名时不会超出活动容量
+        reserved, err := repos.Activities.ReserveSeat(ctx, registration.ActivityID)
+        if err != nil {
+            return err
+        }
+        if !reserved {
+            return errs.ErrActivityFull
+        }
+
+        // 检查是否重复报名，重复时回滚占用的名额
+        isDuplicate, err := repos.Registrations.CheckDuplicateRegistration(ctx, userID, registration.ActivityID)
+        if err != nil {
+            return err
+        }
+        if isDuplicate {
+            return errs.ErrAlreadyRegistered
+        }
+
+        isDuplicate, err = repos.Registrations.CheckDuplicateDocument(ctx, registration.ActivityID, fieldcrypt.BlindIndex(registration.IDCard))
+        if err != nil {
+            return err
+        }
+        if isDuplicate {
+            return errs.ErrDocumentRegistered
         }
-    }()
 
-    // 创建报名记录
-    if err := s.regRepo.Create(registration); err != nil {
-        tx.Rollback()
+        // 创建报名记录
+        return repos.Registrations.Create(ctx, registration)
+    })
+    if err != nil {
         return err
     }
 
-    return tx.Commit().Error
+    s.cache.Invalidate()
+    metrics.RegistrationEvents.WithLabelValues(metrics.RegistrationCreated).Inc()
+    s.logger.InfoContext(ctx, "报名已创建", "registration_id", registration.ID, "activity_id", registration.ActivityID)
+    return nil
 }
 
 // GetUserRegistration 获取用户在某个活动的报名记录
-func (s *registrationService) GetUserRegistration(userID, activityID uint) (*models.Registration, error) {
-    return s.regRepo.FindByUserAndActivity(userID, activityID)
+func (s *registrationService) GetUserRegistration(ctx context.Context, userID, activityID uint) (*models.Registration, error) {
+    ctx, span := tracer.Start(ctx, "RegistrationService.GetUserRegistration")
+    defer span.End()
+
+    registration, err := s.regRepo.FindByUserAndActivity(ctx, userID, activityID)
+    if err != nil {
+        return nil, notFound(err, errs.ErrRegistrationNotFound)
+    }
+    return registration, nil
+}
+
+// GetRegistration 根据ID获取报名记录
+func (s *registrationService) GetRegistration(ctx context.Context, id uint) (*models.Registration, error) {
+    ctx, span := tracer.Start(ctx, "RegistrationService.GetRegistration")
+    defer span.End()
+
+    registration, err := s.regRepo.FindByID(ctx, id)
+    if err != nil {
+        return nil, notFound(err, errs.ErrRegistrationNotFound)
+    }
+    return registration, nil
+}
+
+// applyHolderAttributes 补全证件类型，并以身份证号码中的出生日期和性别为准
+func applyHolderAttributes(registration *models.Registration) error {
+    if registration.DocumentType == "" {
+        registration.DocumentType = models.DocumentIDCard
+    }
+    if registration.DocumentType != models.DocumentIDCard {
+        return nil
+    }
+
+    info, err := idcard.Parse(registration.IDCard)
+    if err != nil {
+        return errs.ErrInvalidRequest.Wrap(err)
+    }
+    registration.BirthDate = &info.BirthDate
+    registration.Gender = info.Gender
+    return nil
+}
+
+// checkAgeEligibility 检查报名人在活动当天的年龄是否满足活动要求
+func checkAgeEligibility(activity *models.Activity, registration *models.Registration) error {
+    if activity.MinAge == 0 && activity.MaxAge == 0 {
+        return nil
+    }
+    if registration.BirthDate == nil {
+        return errs.ErrBirthDateUnknown
+    }
+
+    age := idcard.Age(*registration.BirthDate, activity.Date)
+    if activity.MinAge > 0 && age < activity.MinAge {
+        return errs.ErrAgeBelowMinimum
+    }
+    if activity.MaxAge > 0 && age > activity.MaxAge {
+        return errs.ErrAgeAboveMaximum
+    }
+    return nil
 }
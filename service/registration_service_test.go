@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+	"seaguard-admin-backend/service"
+	"seaguard-admin-backend/utils/fieldcrypt"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 测试专用密钥，与生产配置无关
+const (
+	testEncryptionKeys = "test:c2VhZ3VhcmQtdGVzdC1maWVsZC1lbmNyeXB0aW9uLWs="
+	testBlindIndexKey  = "c2VhZ3VhcmQtdGVzdC1ibGluZC1pbmRleA=="
+)
+
+// openTestDB 在临时目录中创建与生产环境相同连接参数的SQLite数据库
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	keyring, err := fieldcrypt.NewKeyring(testEncryptionKeys, "", testBlindIndexKey, false)
+	if err != nil {
+		t.Fatalf("创建密钥环失败: %v", err)
+	}
+	fieldcrypt.Setup(keyring)
+
+	dsn := filepath.Join(t.TempDir(), "seaguard.db") + "?_busy_timeout=5000&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开数据库失败: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if err := db.AutoMigrate(&models.Activity{}, &models.Category{}, &models.Tag{}, &models.Registration{}, &models.ActivitySeries{}); err != nil {
+		t.Fatalf("迁移表结构失败: %v", err)
+	}
+	return db
+}
+
+// newTestRegistration 构造使用护照报名的记录，避免依赖身份证号码的校验位
+func newTestRegistration(activityID uint, n int) *models.Registration {
+	return &models.Registration{
+		ActivityID:       activityID,
+		Name:             fmt.Sprintf("报名人%d", n),
+		Phone:            "13800138000",
+		DocumentType:     models.DocumentPassport,
+		IDCard:           fmt.Sprintf("E%08d", n),
+		Email:            "z@example.com",
+		EmergencyContact: "李四",
+		EmergencyPhone:   "13800138001",
+	}
+}
+
+// TestRegistrationCapacityUnderConcurrency 并发报名时恰好占满名额，其余报名只因名额已满失败；
+// 随后并发审核时，已占用名额不超过容量，且与待审核和已通过的报名数一致
+func TestRegistrationCapacityUnderConcurrency(t *testing.T) {
+	const (
+		capacity = 10
+		creators = 30
+	)
+
+	db := openTestDB(t)
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	actRepo := repository.NewActivityRepository(db, log)
+	regRepo := repository.NewRegistrationRepository(db, log)
+	svc := service.NewRegistrationService(regRepo, actRepo, repository.NewUnitOfWork(db, log), service.NewActivityCache(time.Minute), log)
+	ctx := context.Background()
+
+	activity := &models.Activity{
+		Title:    "海滩清洁",
+		Date:     time.Now().AddDate(0, 0, 7),
+		Status:   models.ActivityStatusOpen,
+		Location: "青岛市第一海水浴场",
+		Capacity: capacity,
+	}
+	if err := actRepo.Create(ctx, activity); err != nil {
+		t.Fatalf("创建活动失败: %v", err)
+	}
+
+	// 并发报名：只允许名额已满错误，其他错误（如database is locked）说明并发控制有问题
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		created    []uint
+		unexpected []error
+	)
+	for i := 0; i < creators; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			registration := newTestRegistration(activity.ID, n)
+			err := svc.CreateRegistration(ctx, uint(n+1), registration)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created = append(created, registration.ID)
+			case !errors.Is(err, errs.ErrActivityFull):
+				unexpected = append(unexpected, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range unexpected {
+		t.Errorf("报名返回非预期错误: %v", err)
+	}
+	if len(created) != capacity {
+		t.Fatalf("成功报名%d人，期望恰好占满%d个名额", len(created), capacity)
+	}
+	assertSeats(t, db, actRepo, activity.ID, capacity)
+
+	// 并发审核：同一报名的并发修改只有一个成功，其余返回版本冲突；重新占用名额时可能名额已满
+	var updated int
+	unexpected = nil
+	for _, id := range created {
+		for _, status := range []string{models.RegistrationRejected, models.RegistrationApproved, models.RegistrationPending} {
+			wg.Add(1)
+			go func(id uint, status string) {
+				defer wg.Done()
+				_, err := svc.UpdateRegistrationStatus(ctx, id, status, 0)
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					updated++
+				case !errors.Is(err, errs.ErrVersionConflict) && !errors.Is(err, errs.ErrActivityFull):
+					unexpected = append(unexpected, err)
+				}
+			}(id, status)
+		}
+	}
+	wg.Wait()
+	for _, err := range unexpected {
+		t.Errorf("审核返回非预期错误: %v", err)
+	}
+	if updated == 0 {
+		t.Error("并发审核全部失败")
+	}
+	assertSeats(t, db, actRepo, activity.ID, -1)
+}
+
+// assertSeats 校验已占用名额不超过容量且等于待审核和已通过的报名数，want不小于0时还要求已占用名额等于want
+func assertSeats(t *testing.T, db *gorm.DB, actRepo repository.ActivityRepository, activityID uint, want int) {
+	t.Helper()
+
+	current, err := actRepo.FindByID(context.Background(), activityID)
+	if err != nil {
+		t.Fatalf("读取活动失败: %v", err)
+	}
+	if current.Registered > current.Capacity {
+		t.Errorf("已占用名额%d超过容量%d", current.Registered, current.Capacity)
+	}
+	if want >= 0 && current.Registered != want {
+		t.Errorf("已占用名额为%d，期望%d", current.Registered, want)
+	}
+
+	var holding int64
+	err = db.Model(&models.Registration{}).
+		Where("activity_id = ? AND status IN ?", activityID, []string{models.RegistrationPending, models.RegistrationApproved}).
+		Count(&holding).Error
+	if err != nil {
+		t.Fatalf("统计报名失败: %v", err)
+	}
+	if int64(current.Registered) != holding {
+		t.Errorf("已占用名额%d与待审核和已通过的报名数%d不一致", current.Registered, holding)
+	}
+}
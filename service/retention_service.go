@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+	"time"
+)
+
+// RetentionService 数据保留策略服务接口
+type RetentionService interface {
+	Run(ctx context.Context, dryRun bool) (*models.RetentionReport, error)
+	Rules() []models.RetentionRule
+}
+
+type retentionService struct {
+	repo   repository.RetentionRepository
+	audit  AuditService
+	rules  []models.RetentionRule
+	logger *slog.Logger
+}
+
+// NewRetentionService 创建数据保留策略服务实例
+func NewRetentionService(repo repository.RetentionRepository, audit AuditService, rules []models.RetentionRule, logger *slog.Logger) RetentionService {
+	return &retentionService{
+		repo:   repo,
+		audit:  audit,
+		rules:  rules,
+		logger: logger,
+	}
+}
+
+// Rules 返回当前生效的保留规则
+func (s *retentionService) Rules() []models.RetentionRule {
+	return s.rules
+}
+
+// Run 依次执行所有保留规则，dryRun为true时只统计不清除；
+// 单条规则失败不影响其他规则，错误记录在报告中，实际清除的规则写入审计日志
+func (s *retentionService) Run(ctx context.Context, dryRun bool) (*models.RetentionReport, error) {
+	ctx, span := tracer.Start(ctx, "RetentionService.Run")
+	defer span.End()
+
+	now := time.Now()
+	report := &models.RetentionReport{
+		DryRun:    dryRun,
+		StartedAt: now,
+		Results:   make([]models.RetentionRuleResult, 0, len(s.rules)),
+	}
+
+	for _, rule := range s.rules {
+		result := models.RetentionRuleResult{Rule: rule, Cutoff: rule.Cutoff(now)}
+
+		matched, err := s.repo.CountExpired(ctx, rule, result.Cutoff)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "数据保留规则统计失败", "rule", rule.Name, "error", err)
+			result.Error = err.Error()
+			report.Results = append(report.Results, result)
+			continue
+		}
+		result.Matched = matched
+
+		if !dryRun && matched > 0 {
+			affected, err := s.repo.Purge(ctx, rule, result.Cutoff)
+			result.Affected = affected
+			if err != nil {
+				s.logger.ErrorContext(ctx, "数据保留规则执行失败", "rule", rule.Name, "error", err)
+				result.Error = err.Error()
+			}
+			if affected > 0 {
+				if err := s.recordPurge(ctx, result); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if matched > 0 {
+			s.logger.InfoContext(ctx, "数据保留规则已执行", "rule", rule.Name,
+				"matched", result.Matched, "affected", result.Affected, "dry_run", dryRun)
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// recordPurge 记录一次保留规则清除操作，操作人为系统任务
+func (s *retentionService) recordPurge(ctx context.Context, result models.RetentionRuleResult) error {
+	detail, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return s.audit.Record(ctx, &models.AuditLog{
+		Action:     models.AuditActionRetentionPurge,
+		TargetType: result.Rule.Entity,
+		Reason:     result.Rule.Name,
+		Detail:     string(detail),
+	})
+}
@@ -0,0 +1,417 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+	"slices"
+	"time"
+)
+
+// SeriesService 活动系列服务接口：按重复规则在滚动窗口内生成活动场次
+type SeriesService interface {
+	ListSeries(ctx context.Context) ([]models.ActivitySeries, error)
+	GetSeries(ctx context.Context, id uint) (*models.SeriesDetail, error)
+	CreateSeries(ctx context.Context, series *models.ActivitySeries) (*models.SeriesDetail, error)
+	UpdateSeries(ctx context.Context, id uint, series *models.ActivitySeries, from *time.Time, version uint) (*models.SeriesDetail, error)
+	CancelSeries(ctx context.Context, id uint) (*models.SeriesDetail, error)
+	GenerateOccurrences(ctx context.Context) (int, error)
+}
+
+type seriesService struct {
+	repo    repository.SeriesRepository
+	actRepo repository.ActivityRepository
+	uow     repository.UnitOfWork
+	cache   *ActivityCache
+	horizon time.Duration
+	logger  *slog.Logger
+}
+
+// NewSeriesService 创建活动系列服务实例，horizon为提前生成场次的时间范围
+func NewSeriesService(
+	repo repository.SeriesRepository,
+	actRepo repository.ActivityRepository,
+	uow repository.UnitOfWork,
+	cache *ActivityCache,
+	horizon time.Duration,
+	logger *slog.Logger,
+) SeriesService {
+	return &seriesService{
+		repo:    repo,
+		actRepo: actRepo,
+		uow:     uow,
+		cache:   cache,
+		horizon: horizon,
+		logger:  logger,
+	}
+}
+
+// ListSeries 获取所有活动系列
+func (s *seriesService) ListSeries(ctx context.Context) ([]models.ActivitySeries, error) {
+	ctx, span := tracer.Start(ctx, "SeriesService.ListSeries")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
+
+// GetSeries 获取活动系列及其全部场次
+func (s *seriesService) GetSeries(ctx context.Context, id uint) (*models.SeriesDetail, error) {
+	ctx, span := tracer.Start(ctx, "SeriesService.GetSeries")
+	defer span.End()
+
+	series, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, errs.ErrSeriesNotFound)
+	}
+	return s.detail(ctx, series)
+}
+
+// CreateSeries 创建活动系列并生成窗口内的场次
+func (s *seriesService) CreateSeries(ctx context.Context, series *models.ActivitySeries) (*models.SeriesDetail, error) {
+	ctx, span := tracer.Start(ctx, "SeriesService.CreateSeries")
+	defer span.End()
+
+	if !series.Recurrence.Valid(series.StartAt) {
+		return nil, errs.ErrRecurrenceInvalid
+	}
+
+	now := time.Now()
+	series.Status = models.SeriesStatusActive
+	series.GeneratedUntil = now.Add(s.horizon)
+	series.CreatedAt = now
+	series.UpdatedAt = now
+	var created int
+	err := s.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := checkSeriesCategories(ctx, repos, series); err != nil {
+			return err
+		}
+		if err := repos.Series.Create(ctx, series); err != nil {
+			return err
+		}
+		var err error
+		created, err = generateOccurrences(ctx, repos, series, now, series.GeneratedUntil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Invalidate()
+	s.logger.InfoContext(ctx, "活动系列已创建", "series_id", series.ID, "frequency", series.Recurrence.Frequency, "occurrences", created)
+	return s.detail(ctx, series)
+}
+
+// UpdateSeries 修改系列模板和重复规则（修改此后所有场次），version不为0时要求与当前版本一致。
+// 开始时间晚于from（为空或早于当前时间时取当前时间）且未单独修改过的场次：仍符合新规则的按新模板更新，
+// 不再符合的场次从未有人报名时删除、有报名时标记为已取消；新规则产生的场次随后补齐
+func (s *seriesService) UpdateSeries(ctx context.Context, id uint, series *models.ActivitySeries, from *time.Time, version uint) (*models.SeriesDetail, error) {
+	ctx, span := tracer.Start(ctx, "SeriesService.UpdateSeries")
+	defer span.End()
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, errs.ErrSeriesNotFound)
+	}
+	if err := checkVersion(version, existing.Version); err != nil {
+		return nil, err
+	}
+	if existing.Status == models.SeriesStatusCancelled {
+		return nil, errs.ErrSeriesCancelled
+	}
+	if !series.Recurrence.Valid(series.StartAt) {
+		return nil, errs.ErrRecurrenceInvalid
+	}
+
+	now := time.Now()
+	effective := now
+	if from != nil && from.After(now) {
+		effective = *from
+	}
+	existing.Title = series.Title
+	existing.Location = series.Location
+	existing.Capacity = series.Capacity
+	existing.MinAge = series.MinAge
+	existing.MaxAge = series.MaxAge
+	existing.Description = series.Description
+	existing.OrganizerName = series.OrganizerName
+	existing.OrganizerPhone = series.OrganizerPhone
+	existing.OrganizerEmail = series.OrganizerEmail
+	existing.CategoryIDs = series.CategoryIDs
+	existing.Tags = series.Tags
+	existing.StartAt = series.StartAt
+	existing.DurationMinutes = series.DurationMinutes
+	existing.Recurrence = series.Recurrence
+	existing.GeneratedUntil = later(existing.GeneratedUntil, now.Add(s.horizon))
+	existing.UpdatedAt = now
+
+	var updated, retired, created int
+	err = s.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := checkSeriesCategories(ctx, repos, existing); err != nil {
+			return err
+		}
+		categories, tags, err := seriesLabels(ctx, repos, existing)
+		if err != nil {
+			return err
+		}
+
+		starts := make(map[string]time.Time)
+		for _, start := range existing.Occurrences(effective, existing.GeneratedUntil) {
+			starts[start.Format(models.OccurrenceDateLayout)] = start
+		}
+		occurrences, err := repos.Activities.FindBySeries(ctx, id, effective)
+		if err != nil {
+			return err
+		}
+		for i := range occurrences {
+			occurrence := &occurrences[i]
+			if occurrence.SeriesDetached {
+				continue
+			}
+			start, ok := starts[occurrence.Occurrence]
+			if !ok {
+				if err := retireOccurrence(ctx, repos, occurrence); err != nil {
+					return err
+				}
+				retired++
+				continue
+			}
+			applySeriesTemplate(occurrence, existing, start, categories, tags)
+			occurrence.UpdatedAt = now
+			if err := repos.Activities.Update(ctx, occurrence); err != nil {
+				return versionConflict(err)
+			}
+			if err := repos.Activities.ReplaceLabels(ctx, occurrence); err != nil {
+				return err
+			}
+			updated++
+		}
+
+		if created, err = generateOccurrences(ctx, repos, existing, effective, existing.GeneratedUntil); err != nil {
+			return err
+		}
+		return versionConflict(repos.Series.Update(ctx, existing))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Invalidate()
+	s.logger.InfoContext(ctx, "活动系列已更新", "series_id", id, "effective_from", effective,
+		"updated", updated, "retired", retired, "created", created)
+	return s.detail(ctx, existing)
+}
+
+// CancelSeries 取消活动系列：停止生成新场次，尚未开始的场次从未有人报名时删除、有报名时标记为已取消
+func (s *seriesService) CancelSeries(ctx context.Context, id uint) (*models.SeriesDetail, error) {
+	ctx, span := tracer.Start(ctx, "SeriesService.CancelSeries")
+	defer span.End()
+
+	series, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, errs.ErrSeriesNotFound)
+	}
+	if series.Status == models.SeriesStatusCancelled {
+		return s.detail(ctx, series)
+	}
+
+	now := time.Now()
+	series.Status = models.SeriesStatusCancelled
+	series.UpdatedAt = now
+	var retired int
+	err = s.uow.Do(ctx, func(repos *repository.Repositories) error {
+		occurrences, err := repos.Activities.FindBySeries(ctx, id, now)
+		if err != nil {
+			return err
+		}
+		for i := range occurrences {
+			if err := retireOccurrence(ctx, repos, &occurrences[i]); err != nil {
+				return err
+			}
+			retired++
+		}
+		return versionConflict(repos.Series.Update(ctx, series))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Invalidate()
+	s.logger.InfoContext(ctx, "活动系列已取消", "series_id", id, "retired", retired)
+	return s.detail(ctx, series)
+}
+
+// GenerateOccurrences 为所有未取消的系列补齐窗口内的场次，返回新生成的场次数；
+// 单个系列生成失败时记录日志并继续处理其他系列，最后返回遇到的第一个错误
+func (s *seriesService) GenerateOccurrences(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "SeriesService.GenerateOccurrences")
+	defer span.End()
+
+	seriesList, err := s.repo.FindActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	until := now.Add(s.horizon)
+	var total int
+	var firstErr error
+	for _, candidate := range seriesList {
+		var created int
+		err := s.uow.Do(ctx, func(repos *repository.Repositories) error {
+			// 在事务中重新读取系列，避免按已被修改或取消的旧模板生成场次
+			series, err := repos.Series.FindByID(ctx, candidate.ID)
+			if err != nil || series.Status != models.SeriesStatusActive {
+				return err
+			}
+			if created, err = generateOccurrences(ctx, repos, series, now, until); err != nil {
+				return err
+			}
+			return repos.Series.SetGeneratedUntil(ctx, series.ID, later(series.GeneratedUntil, until))
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "生成活动系列场次失败", "series_id", candidate.ID, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		total += created
+	}
+
+	if total > 0 {
+		s.cache.Invalidate()
+		s.logger.InfoContext(ctx, "已生成活动系列场次", "created", total)
+	}
+	return total, firstErr
+}
+
+// detail 查询系列的全部场次
+func (s *seriesService) detail(ctx context.Context, series *models.ActivitySeries) (*models.SeriesDetail, error) {
+	occurrences, err := s.actRepo.FindBySeries(ctx, series.ID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return &models.SeriesDetail{ActivitySeries: *series, Occurrences: occurrences}, nil
+}
+
+// generateOccurrences 生成系列在(from, until]内尚未生成过的场次，已删除的场次不会重新生成
+func generateOccurrences(ctx context.Context, repos *repository.Repositories, series *models.ActivitySeries, from, until time.Time) (int, error) {
+	starts := series.Occurrences(from, until)
+	if len(starts) == 0 {
+		return 0, nil
+	}
+	existing, err := repos.Activities.OccurrenceKeys(ctx, series.ID)
+	if err != nil {
+		return 0, err
+	}
+	categories, tags, err := seriesLabels(ctx, repos, series)
+	if err != nil {
+		return 0, err
+	}
+
+	var created int
+	for _, start := range starts {
+		key := start.Format(models.OccurrenceDateLayout)
+		if slices.Contains(existing, key) {
+			continue
+		}
+		now := time.Now()
+		activity := &models.Activity{
+			SeriesID:   &series.ID,
+			Occurrence: key,
+			Status:     models.ActivityStatusOpen,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		applySeriesTemplate(activity, series, start, categories, tags)
+		if err := repos.Activities.Create(ctx, activity); err != nil {
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+// applySeriesTemplate 按系列模板设置场次的信息，名额不低于场次已占用的名额
+func applySeriesTemplate(activity *models.Activity, series *models.ActivitySeries, start time.Time, categories []models.Category, tags []models.Tag) {
+	activity.Title = series.Title
+	activity.Date = start
+	activity.EndDate = nil
+	if series.DurationMinutes > 0 {
+		end := start.Add(time.Duration(series.DurationMinutes) * time.Minute)
+		activity.EndDate = &end
+	}
+	activity.Location = series.Location
+	activity.Capacity = max(series.Capacity, activity.Registered)
+	activity.MinAge = series.MinAge
+	activity.MaxAge = series.MaxAge
+	activity.Description = series.Description
+	activity.OrganizerName = series.OrganizerName
+	activity.OrganizerPhone = series.OrganizerPhone
+	activity.OrganizerEmail = series.OrganizerEmail
+	activity.Categories = categories
+	activity.Tags = tags
+}
+
+// retireOccurrence 撤下不再举行的场次：从未有人报名时彻底删除，使该日期在规则恢复后可以重新生成；
+// 有报名（含已拒绝的）时标记为已取消，保留报名记录以便通知报名者
+func retireOccurrence(ctx context.Context, repos *repository.Repositories, activity *models.Activity) error {
+	if activity.Status == models.ActivityStatusCancelled {
+		return nil
+	}
+	registrations, err := repos.Registrations.CountByActivityID(ctx, activity.ID)
+	if err != nil {
+		return err
+	}
+	if registrations == 0 {
+		if err := repos.Activities.Delete(ctx, activity.ID); err != nil {
+			return err
+		}
+		return repos.Activities.Purge(ctx, activity.ID)
+	}
+	activity.Status = models.ActivityStatusCancelled
+	activity.UpdatedAt = time.Now()
+	return versionConflict(repos.Activities.Update(ctx, activity))
+}
+
+// checkSeriesCategories 检查系列模板中的分类是否都存在
+func checkSeriesCategories(ctx context.Context, repos *repository.Repositories, series *models.ActivitySeries) error {
+	ids := slices.Compact(slices.Sorted(slices.Values(series.CategoryIDs)))
+	categories, err := repos.Categories.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(categories) != len(ids) {
+		return errs.ErrUnknownCategory
+	}
+	return nil
+}
+
+// seriesLabels 查询系列模板对应的分类和标签；模板创建后被删除的分类直接忽略，标签按需创建
+func seriesLabels(ctx context.Context, repos *repository.Repositories, series *models.ActivitySeries) ([]models.Category, []models.Tag, error) {
+	categories, err := repos.Categories.FindByIDs(ctx, series.CategoryIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, 0, len(series.Tags))
+	for _, tag := range series.Tags {
+		if name := models.NormalizeTag(tag); name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	tags, err := repos.Categories.FindOrCreateTags(ctx, names)
+	if err != nil {
+		return nil, nil, err
+	}
+	return categories, tags, nil
+}
+
+// later 返回两个时间中较晚的一个
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+	"seaguard-admin-backend/service"
+)
+
+// TestUpdateSeriesRestoresRemovedDate 修改规则去掉某个没有报名的日期后再恢复，该日期的场次应重新生成
+func TestUpdateSeriesRestoresRemovedDate(t *testing.T) {
+	db := openTestDB(t)
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	actRepo := repository.NewActivityRepository(db, log)
+	svc := service.NewSeriesService(repository.NewSeriesRepository(db, log), actRepo, repository.NewUnitOfWork(db, log),
+		service.NewActivityCache(time.Minute), 30*24*time.Hour, log)
+	ctx := context.Background()
+
+	startAt := time.Now().AddDate(0, 0, 1).Truncate(time.Hour)
+	template := func(exceptions ...string) *models.ActivitySeries {
+		return &models.ActivitySeries{
+			Title:           "每周海滩清洁",
+			Location:        "青岛市第一海水浴场",
+			Capacity:        10,
+			StartAt:         startAt,
+			DurationMinutes: 120,
+			Recurrence:      models.Recurrence{Frequency: models.RecurrenceWeekly, Exceptions: exceptions},
+		}
+	}
+	occurs := func(detail *models.SeriesDetail, date string) bool {
+		for _, occurrence := range detail.Occurrences {
+			if occurrence.Occurrence == date && occurrence.Status != models.ActivityStatusCancelled {
+				return true
+			}
+		}
+		return false
+	}
+
+	created, err := svc.CreateSeries(ctx, template())
+	if err != nil {
+		t.Fatalf("创建活动系列失败: %v", err)
+	}
+	date := startAt.AddDate(0, 0, 7).Format(models.OccurrenceDateLayout)
+	if !occurs(created, date) {
+		t.Fatalf("创建后没有%s的场次", date)
+	}
+
+	removed, err := svc.UpdateSeries(ctx, created.ID, template(date), nil, 0)
+	if err != nil {
+		t.Fatalf("去掉%s失败: %v", date, err)
+	}
+	if occurs(removed, date) {
+		t.Fatalf("去掉%s后该场次仍然存在", date)
+	}
+
+	restored, err := svc.UpdateSeries(ctx, created.ID, template(), nil, 0)
+	if err != nil {
+		t.Fatalf("恢复%s失败: %v", date, err)
+	}
+	if !occurs(restored, date) {
+		t.Errorf("恢复规则后没有重新生成%s的场次", date)
+	}
+}
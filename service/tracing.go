@@ -0,0 +1,6 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer 服务层span的Tracer，span名称为"接口名.方法名"
+var tracer = otel.Tracer("seaguard-admin-backend/service")
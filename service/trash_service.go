@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/models"
+	"seaguard-admin-backend/repository"
+
+	"gorm.io/gorm"
+)
+
+// TrashService 回收站服务接口，管理已软删除记录的恢复与彻底删除
+type TrashService interface {
+	List(ctx context.Context) (*models.Trash, error)
+	Restore(ctx context.Context, trashType string, id uint) error
+	Purge(ctx context.Context, trashType string, id uint) error
+}
+
+type trashService struct {
+	userRepo      *repository.UserRepository
+	volunteerRepo repository.VolunteerRepository
+	actRepo       repository.ActivityRepository
+	uow           repository.UnitOfWork
+	cache         *ActivityCache
+	logger        *slog.Logger
+}
+
+// NewTrashService 创建回收站服务实例
+func NewTrashService(
+	userRepo *repository.UserRepository,
+	volunteerRepo repository.VolunteerRepository,
+	actRepo repository.ActivityRepository,
+	uow repository.UnitOfWork,
+	cache *ActivityCache,
+	logger *slog.Logger,
+) TrashService {
+	return &trashService{
+		userRepo:      userRepo,
+		volunteerRepo: volunteerRepo,
+		actRepo:       actRepo,
+		uow:           uow,
+		cache:         cache,
+		logger:        logger,
+	}
+}
+
+// List 获取回收站中的用户、志愿者和活动
+func (s *trashService) List(ctx context.Context) (*models.Trash, error) {
+	ctx, span := tracer.Start(ctx, "TrashService.List")
+	defer span.End()
+
+	users, err := s.userRepo.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	volunteers, err := s.volunteerRepo.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	activities, err := s.actRepo.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Trash{
+		Users:      users,
+		Volunteers: volunteers,
+		Activities: activities,
+	}, nil
+}
+
+// Restore 从回收站恢复记录，恢复用户时一并恢复其志愿者信息
+func (s *trashService) Restore(ctx context.Context, trashType string, id uint) error {
+	ctx, span := tracer.Start(ctx, "TrashService.Restore")
+	defer span.End()
+
+	var err error
+	switch trashType {
+	case models.TrashTypeUsers:
+		err = s.uow.Do(ctx, func(repos *repository.Repositories) error {
+			if err := repos.Users.Restore(ctx, id); err != nil {
+				return err
+			}
+			return repos.Volunteers.RestoreByUserID(ctx, id)
+		})
+	case models.TrashTypeVolunteers:
+		err = s.restoreVolunteer(ctx, id)
+	case models.TrashTypeActivities:
+		err = s.actRepo.Restore(ctx, id)
+	default:
+		return errs.ErrTrashTypeInvalid
+	}
+	if err != nil {
+		return notFound(err, errs.ErrTrashItemNotFound)
+	}
+	if trashType == models.TrashTypeActivities {
+		s.cache.Invalidate()
+	}
+
+	s.logger.InfoContext(ctx, "已从回收站恢复", "trash_type", trashType, "target_id", id)
+	return nil
+}
+
+// restoreVolunteer 恢复志愿者信息，所属用户仍在回收站中时不允许单独恢复
+func (s *trashService) restoreVolunteer(ctx context.Context, id uint) error {
+	volunteer, err := s.volunteerRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := s.userRepo.FindByID(ctx, volunteer.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrTrashOwnerDeleted
+		}
+		return err
+	}
+	return s.volunteerRepo.Restore(ctx, id)
+}
+
+// Purge 彻底删除回收站中的记录并按以下规则处理关联数据：
+// 用户——清除其报名记录中的个人信息并解除关联（保留活动报名统计），同时删除志愿者信息；
+// 志愿者——仅删除志愿者信息，报名记录归属于用户账号不受影响；
+// 活动——删除该活动的全部报名记录
+func (s *trashService) Purge(ctx context.Context, trashType string, id uint) error {
+	ctx, span := tracer.Start(ctx, "TrashService.Purge")
+	defer span.End()
+
+	var purge func(repos *repository.Repositories) error
+	switch trashType {
+	case models.TrashTypeUsers:
+		purge = func(repos *repository.Repositories) error {
+			if err := repos.Users.Purge(ctx, id); err != nil {
+				return err
+			}
+			if err := repos.Registrations.ScrubPersonalData(ctx, id, true); err != nil {
+				return err
+			}
+			return repos.Volunteers.PurgeByUserID(ctx, id)
+		}
+	case models.TrashTypeVolunteers:
+		purge = func(repos *repository.Repositories) error {
+			return repos.Volunteers.Purge(ctx, id)
+		}
+	case models.TrashTypeActivities:
+		purge = func(repos *repository.Repositories) error {
+			if err := repos.Activities.Purge(ctx, id); err != nil {
+				return err
+			}
+			return repos.Registrations.DeleteByActivityID(ctx, id)
+		}
+	default:
+		return errs.ErrTrashTypeInvalid
+	}
+
+	// 先删除主记录，记录不在回收站中时整个事务回滚
+	if err := s.uow.Do(ctx, purge); err != nil {
+		return notFound(err, errs.ErrTrashItemNotFound)
+	}
+
+	s.logger.InfoContext(ctx, "已从回收站彻底删除", "trash_type", trashType, "target_id", id)
+	return nil
+}
@@ -1,27 +1,44 @@
 package service
 
 import (
+"context"
 "errors"
+"log/slog"
 "golang.org/x/crypto/bcrypt"
+"gorm.io/gorm"
+"seaguard-admin-backend/errs"
+"seaguard-admin-backend/i18n"
 "seaguard-admin-backend/models"
 "seaguard-admin-backend/repository"
 "seaguard-admin-backend/utils"
-"seaguard-admin-backend/config"
+"strings"
 )
 
 type UserService struct {
 	userRepo *repository.UserRepository
+	uow      repository.UnitOfWork
+	logger   *slog.Logger
 }
 
-func NewUserService(userRepo *repository.UserRepository) *UserService {
-	return &UserService{userRepo: userRepo}
+func NewUserService(userRepo *repository.UserRepository, uow repository.UnitOfWork, logger *slog.Logger) *UserService {
+	return &UserService{userRepo: userRepo, uow: uow, logger: logger}
 }
 
-func (s *UserService) Register(req *models.RegisterRequest) error {
+func (s *UserService) Register(ctx context.Context, req *models.RegisterRequest) error {
+    ctx, span := tracer.Start(ctx, "UserService.Register")
+    defer span.End()
+
+    if req.Role != "admin" && req.Role != "volunteer" {
+        return errs.ErrInvalidRole
+    }
+    if req.Language != "" && !i18n.IsSupported(req.Language) {
+        return errs.ErrUnsupportedLang
+    }
+
     // 检查用户名是否已存在
-    existingUser, _ := s.userRepo.FindByUsername(req.Username)
+    existingUser, _ := s.userRepo.FindByUsername(ctx, req.Username)
     if existingUser != nil {
-        return errors.New("用户名已存在")
+        return errs.ErrUsernameTaken
     }
 
     // 密码加密
@@ -36,24 +53,19 @@ func (s *UserService) Register(req *models.RegisterRequest) error {
         Password: string(hashedPassword),
         Role:     req.Role,
         Status:   "active",
+        Language: req.Language,
     }
 
-    // 开启事务
-    tx := config.DB.Begin()
-    defer func() {
-        if r := recover(); r != nil {
-            tx.Rollback()
+    err = s.uow.Do(ctx, func(repos *repository.Repositories) error {
+        // 创建用户账号
+        if err := repos.Users.Create(ctx, user); err != nil {
+            return err
         }
-    }()
 
-    // 创建用户账号
-    if err := tx.Create(user).Error; err != nil {
-        tx.Rollback()
-        return err
-    }
-
-    // 如果是志愿者角色，创建志愿者信息
-    if req.Role == "volunteer" {
+        // 如果是志愿者角色，创建志愿者信息
+        if req.Role != "volunteer" {
+            return nil
+        }
         volunteer := &models.Volunteer{
             UserID:     user.ID,  // 设置关联的用户ID
             Name:       req.Name,
@@ -62,98 +74,118 @@ func (s *UserService) Register(req *models.RegisterRequest) error {
             Address:    req.Address,
             Hours:      0,
             Activities: 0,
-            Status:     "活跃",
-        }
-        
-        if err := tx.Create(volunteer).Error; err != nil {
-            tx.Rollback()
-            return err
+            Status:     models.VolunteerStatusActive,
         }
+        return repos.Volunteers.Create(ctx, volunteer)
+    })
+    if err != nil {
+        return err
     }
 
-    return tx.Commit().Error
+    s.logger.InfoContext(ctx, "用户已注册", "target_user_id", user.ID, "role", user.Role)
+    return nil
 }
 
-func (s *UserService) Login(username, password string) (*models.User, string, error) {
-	user, err := s.userRepo.FindByUsername(username)
+func (s *UserService) Login(ctx context.Context, username, password string) (*models.User, string, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Login")
+	defer span.End()
+
+	user, err := s.userRepo.FindByUsername(ctx, username)
 	if err != nil {
-		return nil, "", errors.New("用户名或密码错误")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", errs.ErrInvalidCredentials
+		}
+		return nil, "", err
 	}
 
 	// 验证密码
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, "", errors.New("用户名或密码错误")
+		s.logger.WarnContext(ctx, "登录密码错误", "target_user_id", user.ID)
+		return nil, "", errs.ErrInvalidCredentials
 	}
 
 	if user.Status != "active" {
-		return nil, "", errors.New("用户账号已被禁用")
+		s.logger.WarnContext(ctx, "已禁用账号尝试登录", "target_user_id", user.ID, "status", user.Status)
+		return nil, "", errs.ErrAccountDisabled
 	}
 
 	// 生成JWT Token
 	token, err := utils.GenerateToken(user.ID, user.Role)
 	if err != nil {
-		return nil, "", errors.New("生成token失败")
+		return nil, "", err
 	}
 
 	return user, token, nil
 }
 
-func (s *UserService) UpdateUser(user *models.User) error {
-	return s.userRepo.Update(user)
+func (s *UserService) UpdateUser(ctx context.Context, user *models.User) error {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+
+	return versionConflict(s.userRepo.Update(ctx, user))
 }
 
-func (s *UserService) GetUserByID(id uint) (*models.User, error) {
-	return s.userRepo.FindByID(id)
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserByID")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, errs.ErrUserNotFound)
+	}
+	return user, nil
 }
 
-func (s *UserService) ListUsers() ([]models.User, error) {
-	return s.userRepo.List()
+func (s *UserService) ListUsers(ctx context.Context) ([]models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListUsers")
+	defer span.End()
+
+	return s.userRepo.List(ctx)
 }
 
-func (s *UserService) DeleteUser(id uint) error {
-    // 开启事务
-    tx := config.DB.Begin()
-    defer func() {
-        if r := recover(); r != nil {
-            tx.Rollback()
-        }
-    }()
+func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
+    ctx, span := tracer.Start(ctx, "UserService.DeleteUser")
+    defer span.End()
 
-    // 查询用户
-    user, err := s.userRepo.FindByID(id)
-    if err != nil {
-        tx.Rollback()
-        return err
-    }
+    err := s.uow.Do(ctx, func(repos *repository.Repositories) error {
+        // 查询用户
+        user, err := repos.Users.FindByID(ctx, id)
+        if err != nil {
+            return notFound(err, errs.ErrUserNotFound)
+        }
 
-    // 如果是志愿者，先删除志愿者信息（由于设置了CASCADE，这步可以省略）
-    if user.Role == "volunteer" {
-        if err := tx.Where("user_id = ?", id).Delete(&models.Volunteer{}).Error; err != nil {
-            tx.Rollback()
-            return err
+        // 如果是志愿者，同时将志愿者信息移入回收站，恢复用户时一并恢复
+        if user.Role == "volunteer" {
+            if err := repos.Volunteers.DeleteByUserID(ctx, id); err != nil {
+                return err
+            }
         }
-    }
 
-    // 删除用户
-    if err := s.userRepo.Delete(id); err != nil {
-        tx.Rollback()
+        // 软删除用户，报名记录保留至从回收站彻底删除时再处理
+        return repos.Users.Delete(ctx, id)
+    })
+    if err != nil {
         return err
     }
 
-    return tx.Commit().Error
+    s.logger.InfoContext(ctx, "用户已移入回收站", "target_user_id", id)
+    return nil
 }
 
-func (s *UserService) ChangePassword(userID uint, oldPassword, newPassword string) error {
-	user, err := s.userRepo.FindByID(userID)
+func (s *UserService) ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) error {
+	ctx, span := tracer.Start(ctx, "UserService.ChangePassword")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return err
+		return notFound(err, errs.ErrUserNotFound)
 	}
 
 	// 验证旧密码
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword))
 	if err != nil {
-		return errors.New("旧密码错误")
+		return errs.ErrWrongPassword
 	}
 
 	// 加密新密码
@@ -163,15 +195,67 @@ func (s *UserService) ChangePassword(userID uint, oldPassword, newPassword strin
 	}
 
 	user.Password = string(hashedPassword)
-	return s.userRepo.Update(user)
+	return versionConflict(s.userRepo.Update(ctx, user))
 }
 
-func (s *UserService) UpdateStatus(userID uint, status string) error {
-	user, err := s.userRepo.FindByID(userID)
+// UpdateStatus 更新用户状态，version不为0时要求与当前版本一致
+func (s *UserService) UpdateStatus(ctx context.Context, userID uint, status string, version uint) (*models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateStatus")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return err
+		return nil, notFound(err, errs.ErrUserNotFound)
+	}
+	if err := checkVersion(version, user.Version); err != nil {
+		return nil, err
 	}
 
 	user.Status = status
-	return s.userRepo.Update(user)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	s.logger.InfoContext(ctx, "用户状态已更新", "target_user_id", userID, "status", status)
+	return user, nil
+}
+
+// UpdateLanguage 更新用户的界面语言偏好
+func (s *UserService) UpdateLanguage(ctx context.Context, userID uint, language string) error {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateLanguage")
+	defer span.End()
+
+	if !i18n.IsSupported(language) {
+		return errs.ErrUnsupportedLang
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return notFound(err, errs.ErrUserNotFound)
+	}
+
+	user.Language = language
+	return versionConflict(s.userRepo.Update(ctx, user))
+}
+
+// UpdatePermissions 更新用户的附加权限，version不为0时要求与当前版本一致
+func (s *UserService) UpdatePermissions(ctx context.Context, userID uint, permissions []string, version uint) (*models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdatePermissions")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, notFound(err, errs.ErrUserNotFound)
+	}
+	if err := checkVersion(version, user.Version); err != nil {
+		return nil, err
+	}
+
+	user.Permissions = strings.Join(permissions, ",")
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	s.logger.InfoContext(ctx, "用户附加权限已更新", "target_user_id", userID, "permissions", user.Permissions)
+	return user, nil
 }
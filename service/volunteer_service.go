@@ -1,6 +1,9 @@
 package service
 
 import (
+	"context"
+	"log/slog"
+	"seaguard-admin-backend/errs"
 	"seaguard-admin-backend/models"
 	"seaguard-admin-backend/repository"
 	"time"
@@ -8,75 +11,138 @@ import (
 
 // VolunteerService 志愿者服务接口
 type VolunteerService interface {
-GetAllVolunteers() ([]models.Volunteer, error)
-CreateVolunteer(volunteer *models.Volunteer) error
-UpdateVolunteer(id uint, volunteer *models.Volunteer) error
-DeleteVolunteer(id uint) error
-UpdateVolunteerInfo(userID uint, req *models.UpdateVolunteerInfoRequest) error
-GetVolunteerInfo(userID uint) (*models.Volunteer, error)
-FindByUserID(userID uint) (*models.Volunteer, error)
+GetAllVolunteers(ctx context.Context) ([]models.Volunteer, error)
+CreateVolunteer(ctx context.Context, volunteer *models.Volunteer) error
+UpdateVolunteer(ctx context.Context, id uint, volunteer *models.Volunteer, version uint) (*models.Volunteer, error)
+DeleteVolunteer(ctx context.Context, id uint) error
+UpdateVolunteerInfo(ctx context.Context, userID uint, req *models.UpdateVolunteerInfoRequest, version uint) (*models.Volunteer, error)
+GetVolunteerInfo(ctx context.Context, userID uint) (*models.Volunteer, error)
+FindByUserID(ctx context.Context, userID uint) (*models.Volunteer, error)
+GetVolunteer(ctx context.Context, id uint) (*models.Volunteer, error)
 }
 
 type volunteerService struct {
-	repo repository.VolunteerRepository
+	repo   repository.VolunteerRepository
+	logger *slog.Logger
 }
 
 // NewVolunteerService 创建志愿者服务实例
-func NewVolunteerService(repo repository.VolunteerRepository) VolunteerService {
+func NewVolunteerService(repo repository.VolunteerRepository, logger *slog.Logger) VolunteerService {
 	return &volunteerService{
-		repo: repo,
+		repo:   repo,
+		logger: logger,
 	}
 }
 
 // GetAllVolunteers 获取所有志愿者
-func (s *volunteerService) GetAllVolunteers() ([]models.Volunteer, error) {
-	return s.repo.FindAll()
+func (s *volunteerService) GetAllVolunteers(ctx context.Context) ([]models.Volunteer, error) {
+	ctx, span := tracer.Start(ctx, "VolunteerService.GetAllVolunteers")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
 }
 
 // CreateVolunteer 创建志愿者
-func (s *volunteerService) CreateVolunteer(volunteer *models.Volunteer) error {
+func (s *volunteerService) CreateVolunteer(ctx context.Context, volunteer *models.Volunteer) error {
+	ctx, span := tracer.Start(ctx, "VolunteerService.CreateVolunteer")
+	defer span.End()
+
 	volunteer.Hours = 0
 	volunteer.Activities = 0
-	volunteer.Status = "活跃"
+	volunteer.Status = models.VolunteerStatusActive
 	volunteer.CreatedAt = time.Now()
 	volunteer.UpdatedAt = time.Now()
-	return s.repo.Create(volunteer)
+	if err := s.repo.Create(ctx, volunteer); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "志愿者已创建", "volunteer_id", volunteer.ID)
+	return nil
 }
 
-// UpdateVolunteer 更新志愿者
-func (s *volunteerService) UpdateVolunteer(id uint, volunteer *models.Volunteer) error {
-	existingVolunteer, err := s.repo.FindByID(id)
+// UpdateVolunteer 更新志愿者的可编辑字段，version不为0时要求与当前版本一致
+func (s *volunteerService) UpdateVolunteer(ctx context.Context, id uint, volunteer *models.Volunteer, version uint) (*models.Volunteer, error) {
+	ctx, span := tracer.Start(ctx, "VolunteerService.UpdateVolunteer")
+	defer span.End()
+
+	existingVolunteer, err := s.repo.FindByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, notFound(err, errs.ErrVolunteerNotFound)
+	}
+	if err := checkVersion(version, existingVolunteer.Version); err != nil {
+		return nil, err
 	}
 
-	volunteer.ID = id
-	volunteer.UpdatedAt = time.Now()
-	volunteer.Hours = existingVolunteer.Hours
-	volunteer.Activities = existingVolunteer.Activities
-	return s.repo.Update(volunteer)
+	existingVolunteer.Name = volunteer.Name
+	existingVolunteer.Phone = volunteer.Phone
+	existingVolunteer.Email = volunteer.Email
+	existingVolunteer.Address = volunteer.Address
+	existingVolunteer.Status = volunteer.Status
+	existingVolunteer.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, existingVolunteer); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	s.logger.InfoContext(ctx, "志愿者信息已更新", "volunteer_id", existingVolunteer.ID, "version", existingVolunteer.Version)
+	return existingVolunteer, nil
 }
 
 // DeleteVolunteer 删除志愿者
-func (s *volunteerService) DeleteVolunteer(id uint) error {
-return s.repo.Delete(id)
+func (s *volunteerService) DeleteVolunteer(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "VolunteerService.DeleteVolunteer")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "志愿者已移入回收站", "volunteer_id", id)
+	return nil
 }
 
 // GetVolunteerInfo 获取志愿者个人信息
-func (s *volunteerService) GetVolunteerInfo(userID uint) (*models.Volunteer, error) {
-    return s.repo.FindByUserID(userID)
+func (s *volunteerService) GetVolunteerInfo(ctx context.Context, userID uint) (*models.Volunteer, error) {
+    ctx, span := tracer.Start(ctx, "VolunteerService.GetVolunteerInfo")
+    defer span.End()
+
+    volunteer, err := s.repo.FindByUserID(ctx, userID)
+    if err != nil {
+        return nil, notFound(err, errs.ErrVolunteerNotFound)
+    }
+    return volunteer, nil
 }
 
 // FindByUserID 根据用户ID查找志愿者
-func (s *volunteerService) FindByUserID(userID uint) (*models.Volunteer, error) {
-    return s.repo.FindByUserID(userID)
+func (s *volunteerService) FindByUserID(ctx context.Context, userID uint) (*models.Volunteer, error) {
+    ctx, span := tracer.Start(ctx, "VolunteerService.FindByUserID")
+    defer span.End()
+
+    return s.repo.FindByUserID(ctx, userID)
 }
 
-// UpdateVolunteerInfo 更新志愿者个人信息
-func (s *volunteerService) UpdateVolunteerInfo(userID uint, req *models.UpdateVolunteerInfoRequest) error {
-existingVolunteer, err := s.repo.FindByUserID(userID)
+// GetVolunteer 根据ID获取志愿者
+func (s *volunteerService) GetVolunteer(ctx context.Context, id uint) (*models.Volunteer, error) {
+    ctx, span := tracer.Start(ctx, "VolunteerService.GetVolunteer")
+    defer span.End()
+
+    volunteer, err := s.repo.FindByID(ctx, id)
+    if err != nil {
+        return nil, notFound(err, errs.ErrVolunteerNotFound)
+    }
+    return volunteer, nil
+}
+
+// UpdateVolunteerInfo 更新志愿者个人信息，version不为0时要求与当前版本一致
+func (s *volunteerService) UpdateVolunteerInfo(ctx context.Context, userID uint, req *models.UpdateVolunteerInfoRequest, version uint) (*models.Volunteer, error) {
+	ctx, span := tracer.Start(ctx, "VolunteerService.UpdateVolunteerInfo")
+	defer span.End()
+
+existingVolunteer, err := s.repo.FindByUserID(ctx, userID)
 	if err != nil {
-		return err
+		return nil, notFound(err, errs.ErrVolunteerNotFound)
+	}
+	if err := checkVersion(version, existingVolunteer.Version); err != nil {
+		return nil, err
 	}
 
 	// 只更新允许的字段
@@ -86,5 +152,10 @@ existingVolunteer, err := s.repo.FindByUserID(userID)
 	existingVolunteer.Address = req.Address
 	existingVolunteer.UpdatedAt = time.Now()
 
-	return s.repo.Update(existingVolunteer)
+	if err := s.repo.Update(ctx, existingVolunteer); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	s.logger.InfoContext(ctx, "志愿者信息已更新", "volunteer_id", existingVolunteer.ID, "version", existingVolunteer.Version)
+	return existingVolunteer, nil
 }
@@ -0,0 +1,181 @@
+// Package fieldcrypt 实现敏感字段的AES-GCM加密与盲索引
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 密文格式：enc:v1:<keyID>:<base64(nonce|ciphertext)>
+const (
+	prefix  = "enc:"
+	version = "v1"
+)
+
+// 仅供开发环境使用的默认密钥
+const (
+	devKeyID    = "dev"
+	devKey      = "c2VhZ3VhcmQtZGV2LWZpZWxkLWVuY3J5cHRpb24tayE="
+	devIndexKey = "c2VhZ3VhcmQtZGV2LWJsaW5kLWluZGV4LWtleQ=="
+)
+
+var (
+	ErrUnknownKey  = errors.New("未知的加密密钥ID")
+	ErrCiphertext  = errors.New("密文格式错误")
+	ErrKeyConfig   = errors.New("加密密钥配置错误")
+	defaultKeyring *Keyring
+)
+
+// Keyring 字段加密密钥环，支持多个密钥以便轮换
+type Keyring struct {
+	aeads    map[string]cipher.AEAD
+	activeID string
+	indexKey []byte
+}
+
+// NewKeyring 根据配置创建密钥环
+// keys格式为"keyID:base64密钥,keyID:base64密钥"，activeID为空时使用第一个密钥加密；
+// 未配置密钥时返回ErrKeyConfig，仅devMode为true时改用开发环境的默认密钥
+func NewKeyring(keys, activeID, indexKey string, devMode bool) (*Keyring, error) {
+	if devMode && keys == "" {
+		keys = devKeyID + ":" + devKey
+	}
+	if devMode && indexKey == "" {
+		indexKey = devIndexKey
+	}
+	if keys == "" || indexKey == "" {
+		return nil, fmt.Errorf("%w: 未配置字段加密密钥或盲索引密钥", ErrKeyConfig)
+	}
+
+	k := &Keyring{aeads: make(map[string]cipher.AEAD)}
+	for _, entry := range strings.Split(keys, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("%w: %q", ErrKeyConfig, entry)
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(raw) != 32 {
+			return nil, fmt.Errorf("%w: 密钥%s必须是32字节的base64编码", ErrKeyConfig, id)
+		}
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		k.aeads[id] = aead
+		if k.activeID == "" {
+			k.activeID = id
+		}
+	}
+	if activeID != "" {
+		if _, ok := k.aeads[activeID]; !ok {
+			return nil, fmt.Errorf("%w: 当前密钥%s不存在", ErrKeyConfig, activeID)
+		}
+		k.activeID = activeID
+	}
+
+	rawIndexKey, err := base64.StdEncoding.DecodeString(indexKey)
+	if err != nil || len(rawIndexKey) < 16 {
+		return nil, fmt.Errorf("%w: 盲索引密钥至少16字节", ErrKeyConfig)
+	}
+	k.indexKey = rawIndexKey
+	return k, nil
+}
+
+// Setup 设置全局密钥环，GORM序列化器使用该密钥环
+func Setup(k *Keyring) {
+	defaultKeyring = k
+}
+
+// Encrypt 使用当前密钥加密明文，空字符串不加密
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	aead := k.aeads[k.activeID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(k.activeID))
+	return prefix + version + ":" + k.activeID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密密文，未加密的历史数据原样返回
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, prefix), ":", 3)
+	if len(parts) != 3 || parts[0] != version {
+		return "", ErrCiphertext
+	}
+	aead, ok := k.aeads[parts[1]]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, parts[1])
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrCiphertext
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(parts[1]))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// ActiveKeyID 返回用于加密新数据的密钥ID
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// ActiveKeyID 返回全局密钥环用于加密新数据的密钥ID
+func ActiveKeyID() string {
+	return defaultKeyring.ActiveKeyID()
+}
+
+// KeyID 返回密文使用的密钥ID，未加密时返回空字符串
+func KeyID(value string) string {
+	if !IsEncrypted(value) {
+		return ""
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, prefix), ":", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+// IsEncrypted 判断值是否为本包生成的密文
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// BlindIndex 计算值的盲索引，用于在不解密的情况下进行等值查询
+func (k *Keyring) BlindIndex(value string) string {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	if normalized == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(normalized))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// BlindIndex 使用全局密钥环计算盲索引
+func BlindIndex(value string) string {
+	return defaultKeyring.BlindIndex(value)
+}
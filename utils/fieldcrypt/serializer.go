@@ -0,0 +1,49 @@
+package fieldcrypt
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName GORM序列化器名称，在模型字段上使用gorm:"serializer:encrypted"
+const SerializerName = "encrypted"
+
+func init() {
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+// Serializer 透明加解密字符串字段的GORM序列化器
+type Serializer struct{}
+
+// Scan 从数据库读取时解密
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("字段%s不支持的加密数据类型%T", field.Name, dbValue)
+	}
+
+	plaintext, err := defaultKeyring.Decrypt(value)
+	if err != nil {
+		return fmt.Errorf("解密字段%s失败: %w", field.Name, err)
+	}
+	field.ReflectValueOf(ctx, dst).SetString(plaintext)
+	return nil
+}
+
+// Value 写入数据库时加密
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("字段%s必须为string类型才能加密", field.Name)
+	}
+	return defaultKeyring.Encrypt(value)
+}
@@ -0,0 +1,105 @@
+// Package idcard 实现中国居民身份证号码（GB 11643-1999）的校验与解析
+package idcard
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// 性别
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+)
+
+var (
+	ErrLength   = errors.New("身份证号码必须为18位")
+	ErrFormat   = errors.New("身份证号码格式错误")
+	ErrRegion   = errors.New("身份证号码地区码无效")
+	ErrBirth    = errors.New("身份证号码出生日期无效")
+	ErrChecksum = errors.New("身份证号码校验码错误")
+)
+
+// 省级行政区划代码，81、82、83为港澳台居民居住证
+var provinceCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true,
+	"21": true, "22": true, "23": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "36": true, "37": true,
+	"41": true, "42": true, "43": true, "44": true, "45": true, "46": true,
+	"50": true, "51": true, "52": true, "53": true, "54": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true,
+	"71": true, "81": true, "82": true, "83": true,
+}
+
+var (
+	weights    = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	checkCodes = "10X98765432"
+)
+
+// Info 从身份证号码中解析出的信息
+type Info struct {
+	RegionCode string
+	BirthDate  time.Time
+	Gender     string
+}
+
+// Parse 校验并解析18位居民身份证号码
+func Parse(number string) (*Info, error) {
+	number = strings.ToUpper(strings.TrimSpace(number))
+	if len(number) != 18 {
+		return nil, ErrLength
+	}
+
+	sum := 0
+	for i := 0; i < 17; i++ {
+		c := number[i]
+		if c < '0' || c > '9' {
+			return nil, ErrFormat
+		}
+		sum += int(c-'0') * weights[i]
+	}
+	last := number[17]
+	if (last < '0' || last > '9') && last != 'X' {
+		return nil, ErrFormat
+	}
+
+	if !provinceCodes[number[:2]] {
+		return nil, ErrRegion
+	}
+
+	birth, err := time.ParseInLocation("20060102", number[6:14], time.Local)
+	if err != nil || birth.Year() < 1900 || birth.After(time.Now()) {
+		return nil, ErrBirth
+	}
+
+	if checkCodes[sum%11] != last {
+		return nil, ErrChecksum
+	}
+
+	gender := GenderFemale
+	if (number[16]-'0')%2 == 1 {
+		gender = GenderMale
+	}
+
+	return &Info{
+		RegionCode: number[:6],
+		BirthDate:  birth,
+		Gender:     gender,
+	}, nil
+}
+
+// Valid 判断是否为有效的18位居民身份证号码
+func Valid(number string) bool {
+	_, err := Parse(number)
+	return err == nil
+}
+
+// Age 计算出生日期在指定时间点的周岁年龄
+func Age(birthDate, at time.Time) int {
+	age := at.Year() - birthDate.Year()
+	if at.Month() < birthDate.Month() || at.Month() == birthDate.Month() && at.Day() < birthDate.Day() {
+		age--
+	}
+	return age
+}
@@ -0,0 +1,51 @@
+package utils
+
+import "strings"
+
+// MaskIDCard 脱敏证件号码，保留前6位和后4位，如110101********1234
+func MaskIDCard(value string) string {
+	return maskMiddle(value, 6, 4)
+}
+
+// MaskPhone 脱敏手机号码，保留前3位和后4位，如138****8000
+func MaskPhone(value string) string {
+	return maskMiddle(value, 3, 4)
+}
+
+// MaskName 脱敏姓名，仅保留第一个字，如张*
+func MaskName(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 1 {
+		return value
+	}
+	return string(runes[0]) + strings.Repeat("*", len(runes)-1)
+}
+
+// MaskEmail 脱敏邮箱，仅保留用户名首字符和域名，如z***@example.com
+func MaskEmail(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at <= 0 {
+		return maskMiddle(value, 1, 0)
+	}
+	local := []rune(value[:at])
+	return string(local[0]) + "***" + value[at:]
+}
+
+// MaskAddress 脱敏地址，保留前6个字
+func MaskAddress(value string) string {
+	return maskMiddle(value, 6, 0)
+}
+
+// maskMiddle 保留首尾指定长度的字符，其余替换为*
+func maskMiddle(value string, keepStart, keepEnd int) string {
+	runes := []rune(value)
+	if len(runes) <= keepStart+keepEnd {
+		if len(runes) <= 1 {
+			return strings.Repeat("*", len(runes))
+		}
+		return string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+	return string(runes[:keepStart]) +
+		strings.Repeat("*", len(runes)-keepStart-keepEnd) +
+		string(runes[len(runes)-keepEnd:])
+}
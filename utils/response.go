@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"seaguard-admin-backend/errs"
+	"seaguard-admin-backend/i18n"
+	"seaguard-admin-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// CodeOK 成功响应的业务码
+const CodeOK = "OK"
+
+// StatusClientClosedRequest 客户端在响应前断开连接时记录的状态码（沿用nginx的499约定）
+const StatusClientClosedRequest = 499
+
+// RespondOK 以统一响应结构返回成功结果，messageID为i18n消息ID
+func RespondOK(c *gin.Context, status int, messageID string, data interface{}) {
+	lang := i18n.FromContext(c)
+	c.Header("Content-Language", lang)
+	c.JSON(status, models.Response{
+		Code:    CodeOK,
+		Message: i18n.T(lang, messageID),
+		Data:    data,
+	})
+}
+
+// RespondError 将错误映射为HTTP状态码和错误码后以统一响应结构返回
+func RespondError(c *gin.Context, err error) {
+	RespondErrorWithData(c, err, nil)
+}
+
+// RespondErrorWithData 与RespondError相同，同时在data中附带诊断信息
+func RespondErrorWithData(c *gin.Context, err error, data interface{}) {
+	// 客户端已断开连接，无需再写出响应
+	if errors.Is(err, context.Canceled) {
+		c.Status(StatusClientClosedRequest)
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = errs.ErrRequestTimeout.Wrap(err)
+	}
+
+	e, ok := errs.As(err)
+	if !ok {
+		e = errs.ErrInternal.Wrap(err)
+	}
+	if e.Kind == errs.KindInternal {
+		slog.ErrorContext(c.Request.Context(), "内部错误", "error", err)
+	}
+
+	lang := i18n.FromContext(c)
+	message := e.Message
+	if i18n.Has(e.Code) {
+		message = i18n.T(lang, e.Code)
+	}
+	c.Header("Content-Language", lang)
+	c.JSON(e.Kind.HTTPStatus(), models.Response{
+		Code:    e.Code,
+		Message: message,
+		Data:    data,
+		Errors:  fieldErrors(lang, e.Err),
+	})
+}
+
+// AbortWithError 返回错误响应并终止后续处理
+func AbortWithError(c *gin.Context, err error) {
+	RespondError(c, err)
+	c.Abort()
+}
+
+// fieldErrors 将请求绑定错误转换为指定语言的字段错误列表
+func fieldErrors(lang string, err error) []models.FieldError {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		trans := i18n.Translator(lang)
+		result := make([]models.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			result = append(result, models.FieldError{
+				Field:   fe.Field(),
+				Rule:    fe.Tag(),
+				Message: fe.Translate(trans),
+			})
+		}
+		return result
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []models.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: i18n.T(lang, i18n.MsgFieldType, typeErr.Field, typeErr.Type.String()),
+		}}
+	}
+	return nil
+}